@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/aca/gosh/utils"
+	"github.com/spf13/cobra"
+)
+
+// addAliases adds the aliases of c to the group of their first function,
+// among groups. Aliases of other groups are ignored.
+func addAliases(c *utils.Config, groups []*cobra.Command) error {
+	for _, name := range c.AliasNames() {
+		expr := c.Aliases[name]
+		pl, err := utils.ParsePipeline(expr, pipeGroups...)
+		if err != nil {
+			return &utils.ConfigError{Path: utils.ConfigPath(), Err: fmt.Errorf("alias %s: %v", name, err)}
+		}
+
+		for _, g := range groups {
+			if g.Name() != pl.Group() {
+				continue
+			}
+			if c, _, err := g.Find([]string{name}); err == nil && c != g {
+				return &utils.ConfigError{Path: utils.ConfigPath(), Err: fmt.Errorf("alias %s: %s already has a command %s", name, g.Name(), name)}
+			}
+			g.AddCommand(newAliasCommand(name, expr, pl))
+		}
+	}
+	return nil
+}
+
+func newAliasCommand(name, expr string, pl *utils.Pipeline) *cobra.Command {
+	return &cobra.Command{
+		Use:                   name + " [s]",
+		Short:                 "Alias for " + expr,
+		Long:                  "Alias for gosh pipe '" + expr + "', defined in " + utils.ConfigPath() + ".",
+		Args:                  cobra.RangeArgs(0, 1),
+		DisableFlagsInUseLine: true,
+		RunE: utils.Run(1, func(cmd *cobra.Command, args []string, p *utils.Printer) error {
+			return runPipeline(pl, args[0], p)
+		}),
+	}
+}
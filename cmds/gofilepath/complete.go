@@ -0,0 +1,12 @@
+package gofilepath
+
+import (
+	"github.com/aca/gosh/registry"
+	"github.com/spf13/cobra"
+)
+
+// completeParam completes every argument as a file name, leaving it to the
+// shell.
+func completeParam(p registry.Param, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return nil, cobra.ShellCompDirectiveDefault
+}
@@ -0,0 +1,68 @@
+package gofilepath
+
+import "github.com/aca/gosh/utils"
+
+// examples are the examples of the commands, by name. They only use paths
+// under /, which exist on every unix system, and those of other systems are
+// emulated with --os.
+var examples = map[string][]utils.Example{
+	"abs": {
+		{Args: []string{"/a/../b"}, Output: "/b"},
+	},
+	"base": {
+		{Args: []string{"/a/b.go"}, Output: "b.go"},
+		{Args: []string{"-l"}, Stdin: "a/b\nc/d\n", Output: "b\nd\n"},
+	},
+	"clean": {
+		{Args: []string{"a//b/./c/.."}, Output: "a/b"},
+		{Args: []string{"--os", "windows", `C:/a/../b\c`}, Output: `C:\b\c`},
+	},
+	"dir": {
+		{Args: []string{"/a/b/c"}, Output: "/a/b"},
+	},
+	"fromslash": {
+		{Args: []string{"--os", "windows", "a/b"}, Output: `a\b`},
+	},
+	"evalsymlinks": {
+		{Args: []string{"/"}, Output: "/"},
+		{Args: []string{"/nonexistent/gosh"}, Exit: utils.ExitFailure},
+	},
+	"ext": {
+		{Args: []string{"index.html"}, Output: ".html"},
+	},
+	"glob": {
+		{Args: []string{"/"}, Output: "/\n"},
+		{Args: []string{"["}, Exit: utils.ExitParse},
+	},
+	"isabs": {
+		{Args: []string{"/home/gopher"}},
+		{Args: []string{".bashrc"}, Exit: utils.ExitFalse},
+		{Args: []string{"--os", "windows", `\\host\share\file`}},
+		{Args: []string{"--os", "windows", `\file`}, Exit: utils.ExitFalse},
+	},
+	"join": {
+		{Args: []string{"a", "b/c", "../d"}, Output: "a/b/d"},
+		{Args: []string{"--os", "windows", "C:", "Program Files", "app"}, Output: `C:Program Files\app`},
+	},
+	"rel": {
+		{Args: []string{"/a", "/a/b/c"}, Output: "b/c"},
+		{Args: []string{"/a", "b"}, Exit: utils.ExitFailure},
+	},
+	"split": {
+		{Args: []string{"static/myfile.css"}, Output: "static/\nmyfile.css\n"},
+		{Args: []string{"static/myfile.css", "-o", "json"}, Output: `{"dir":"static/","file":"myfile.css"}` + "\n"},
+		{Args: []string{"static/myfile.css", "-o", "template={{.file}} in {{.dir}}"}, Output: "myfile.css in static/\n"},
+	},
+	"splitlist": {
+		{Args: []string{"/a/b:/c"}, Output: "/a/b\n/c\n"},
+		{Args: []string{"--os", "windows", `C:\a;"C:\b;c"`}, Output: "C:\\a\nC:\\b;c\n"},
+	},
+	"toslash": {
+		{Args: []string{"--os", "windows", `a\b`}, Output: "a/b"},
+	},
+	"volumename": {
+		{Args: []string{"/a/b"}, Output: ""},
+		{Args: []string{"--os", "windows", `C:\a\b`}, Output: "C:"},
+		{Args: []string{"--os", "windows", `\\host\share\a`}, Output: `\\host\share`},
+	},
+}
@@ -0,0 +1,191 @@
+package gofilepath
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/aca/gosh/utils"
+	"github.com/aca/gosh/utils/cmdtest"
+)
+
+func TestCommands(t *testing.T) {
+	cmdtest.Run(t, Cmd, []utils.Example{
+		{Args: []string{"base", "/a/b.go"}, Output: "b.go"},
+		{Args: []string{"base"}, Stdin: "/a/b.go", Output: "b.go"},
+		{Args: []string{"base", "-l"}, Stdin: "a/b\nc/d\n", Output: "b\nd\n"},
+		{Args: []string{"dir", "-z"}, Stdin: "a/b\x00c/d\x00", Output: "a\x00c\x00"},
+		{Args: []string{"split", "a/b", "-o", "json"}, Output: `{"dir":"a/","file":"b"}` + "\n"},
+		{Args: []string{"split", "a/b", "-o", "shell"}, Output: "dir=a/\nfile=b\n"},
+		{Args: []string{"split", "-l", "-o", "template={{.dir | trimsuffix \"/\"}}"}, Stdin: "a/b\nc/d/e\n", Output: "a\nc/d\n"},
+		{Args: []string{"split", "a/b", "-o", "template={{.bogus}}"}, Exit: utils.ExitFailure},
+		{Args: []string{"split", "a/b", "-o", "template={{"}, Exit: utils.ExitUsage},
+		{Args: []string{"join", "a", "b", "c"}, Output: "a/b/c"},
+		{Args: []string{"join"}, Output: ""},
+		{Args: []string{"isabs", "/a"}},
+		{Args: []string{"isabs", "a"}, Exit: utils.ExitFalse},
+		{Args: []string{"glob", "["}, Exit: utils.ExitParse},
+		{Args: []string{"rel", "/a", "b"}, Exit: utils.ExitFailure},
+		{Args: []string{"rel"}, Exit: utils.ExitUsage},
+		{Args: []string{"--os", "windows", "dir", `\\host\share\file`}, Output: `\\host\share\`},
+		{Args: []string{"--os", "windows", "base", `C:\`}, Output: `\`},
+		{Args: []string{"--os", "windows", "rel", `C:\A`, `c:\a\b`}, Output: "b"},
+		{Args: []string{"--os", "windows", "rel", `C:\a`, `D:\a`}, Exit: utils.ExitFailure},
+		{Args: []string{"--os", "windows", "split", `C:\a\b.txt`, "-o", "json"}, Output: `{"dir":"C:\\a\\","file":"b.txt"}` + "\n"},
+		{Args: []string{"--os", "windows", "ext", `a.b\c`}, Output: ""},
+		{Args: []string{"--os", "windows", "join", `\`, `\x`}, Output: `\x`},
+		{Args: []string{"--os", "windows", "isabs", `\\host\share`}},
+		{Args: []string{"--os", "windows", "clean", `a/../c:b`}, Output: `.\c:b`},
+		{Args: []string{"--os", "plan9", "isabs", "#c"}},
+		{Args: []string{"--os", "plan9", "splitlist", "a:b\x00c"}, Output: "a:b\nc\n"},
+		{Args: []string{"--os", "bsd", "clean", "a"}, Exit: utils.ExitUsage},
+		{Args: []string{"--os", "unix", "abs", "a"}, Exit: utils.ExitUsage},
+	})
+}
+
+func TestExamples(t *testing.T) {
+	cmdtest.Examples(t, Cmd)
+}
+
+// The emulation of unix matches path/filepath on unix hosts.
+func TestPathOSHost(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "plan9" {
+		t.Skip("host is not unix")
+	}
+	o := lookupPathOS("unix")
+	paths := []string{"", ".", "..", "/", "//", "a", "a/", "/a/b/../c", "../../a", "a/./b//c/", "/..", "a.b/c.d", ".x"}
+	for _, p := range paths {
+		if got, want := o.Clean(p), filepath.Clean(p); got != want {
+			t.Errorf("Clean(%q) = %q, want %q", p, got, want)
+		}
+		if got, want := o.Base(p), filepath.Base(p); got != want {
+			t.Errorf("Base(%q) = %q, want %q", p, got, want)
+		}
+		if got, want := o.Dir(p), filepath.Dir(p); got != want {
+			t.Errorf("Dir(%q) = %q, want %q", p, got, want)
+		}
+		if got, want := o.Ext(p), filepath.Ext(p); got != want {
+			t.Errorf("Ext(%q) = %q, want %q", p, got, want)
+		}
+		if got, want := o.IsAbs(p), filepath.IsAbs(p); got != want {
+			t.Errorf("IsAbs(%q) = %v, want %v", p, got, want)
+		}
+		for _, q := range paths {
+			if got, want := o.Join(p, q), filepath.Join(p, q); got != want {
+				t.Errorf("Join(%q, %q) = %q, want %q", p, q, got, want)
+			}
+			got, gotErr := o.Rel(p, q)
+			want, wantErr := filepath.Rel(p, q)
+			if got != want || (gotErr == nil) != (wantErr == nil) {
+				t.Errorf("Rel(%q, %q) = %q, %v; want %q, %v", p, q, got, gotErr, want, wantErr)
+			}
+		}
+	}
+}
+
+// The emulation of windows matches path/filepath on windows hosts, whose
+// outputs are taken from the tests of the standard library.
+func TestPathOSWindows(t *testing.T) {
+	o := lookupPathOS("windows")
+
+	clean := []struct{ path, want string }{
+		{`c:`, `c:.`},
+		{`c:\abc\def\..\..`, `c:\`},
+		{`c:abc\..\..\.\.\..\def`, `c:..\..\def`},
+		{`/`, `\`},
+		{`\\i\..\c$`, `\c$`},
+		{`\\..\..\a`, `\a`},
+		{`//host/share/foo/../baz`, `\\host\share\baz`},
+		{`\\host\share\foo\..\..\..\..\bar`, `\\host\share\bar`},
+		{`\\?\UNC\host\share\foo\..\..\..\..\bar`, `\\?\UNC\host\share\bar`},
+		{`\\.\C:\a\..\..\..\..\bar`, `\\.\C:\bar`},
+		{`\\?\C:\`, `\\?\C:\`},
+		{`///abc`, `\\\abc`},
+		{`a/../c:`, `.\c:`},
+		{`a/../c:/a`, `.\c:\a`},
+		{`a/../../c:`, `..\c:`},
+		{`a/../c:b`, `.\c:b`},
+		{`foo:bar`, `foo:bar`},
+		{`/a/../??/a`, `\.\??\a`},
+	}
+	for _, tt := range clean {
+		if got := o.Clean(tt.path); got != tt.want {
+			t.Errorf("Clean(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+
+	isAbs := []struct {
+		path string
+		want bool
+	}{
+		{`C:\`, true},
+		{`c:`, false},
+		{`\Windows`, false},
+		{`c:/a/b`, true},
+		{`\\host\share`, true},
+		{`//host/share/foo/bar`, true},
+		{`\\..\..\a`, false},
+		{`//?/../x`, false},
+		{`\\?\a\b\c`, true},
+		{`\??\a\b\c`, true},
+		{`\\.\COM1`, true},
+	}
+	for _, tt := range isAbs {
+		if got := o.IsAbs(tt.path); got != tt.want {
+			t.Errorf("IsAbs(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+
+	volumes := []struct{ path, want string }{
+		{`c:/foo/bar`, `c:`},
+		{`2:`, `2:`},
+		{`\\\host\share`, `\\\host`},
+		{`//host/`, `\\host\`},
+		{`//host/share/foo`, `\\host\share`},
+		{`//./UNC/../share`, ``},
+		{`//.../share`, `\\...\share`},
+		{`//./NUL`, `\\.\NUL`},
+		{`\\.\COM1`, `\\.\COM1`},
+		{`//?/`, `\\?\`},
+		{`/??/NUL`, `\??\NUL`},
+		{`//./C:/a/b/c`, `\\.\C:`},
+		{`//?/UNC/host/share/a/b/c`, `\\?\UNC\host\share`},
+		{`//./UNC/host\`, `\\.\UNC\host\`},
+	}
+	for _, tt := range volumes {
+		if got := o.VolumeName(tt.path); got != tt.want {
+			t.Errorf("VolumeName(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+
+	joins := []struct {
+		elem []string
+		want string
+	}{
+		{[]string{`C:`, ``, `b`}, `C:b`},
+		{[]string{`C:`, `\a`}, `C:\a`},
+		{[]string{`C:.`, `a`}, `C:a`},
+		{[]string{`//host/share`, `foo/bar`}, `\\host\share\foo\bar`},
+		{[]string{`\\`, `a`, `b`}, `\\a\b`},
+		{[]string{`\`, `\\a\b`, `c`}, `\a\b\c`},
+		{[]string{`a:\b\c`, `x\..\y:\..\..\z`}, `a:\b\z`},
+		{[]string{`\`, `??\a`}, `\.\??\a`},
+	}
+	for _, tt := range joins {
+		if got := o.Join(tt.elem...); got != tt.want {
+			t.Errorf("Join(%q) = %q, want %q", tt.elem, got, tt.want)
+		}
+	}
+
+	dirs := []struct{ path, want string }{
+		{`c:.`, `c:.`},
+		{`\\host\share`, `\\host\share`},
+		{`\\host\share\a`, `\\host\share\`},
+		{`\\\\`, `\\\\`},
+	}
+	for _, tt := range dirs {
+		if got := o.Dir(tt.path); got != tt.want {
+			t.Errorf("Dir(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
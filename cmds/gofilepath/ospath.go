@@ -0,0 +1,521 @@
+package gofilepath
+
+import (
+	"errors"
+	"strings"
+)
+
+// pathOS implements the lexical functions of path/filepath with the rules of
+// an operating system, whatever the host is. It follows the implementations
+// of the standard library for each system.
+type pathOS struct {
+	name    string
+	sep     byte
+	listSep byte
+}
+
+// pathOSes are the systems --os emulates.
+var pathOSes = []*pathOS{
+	{name: "unix", sep: '/', listSep: ':'},
+	{name: "windows", sep: '\\', listSep: ';'},
+	{name: "plan9", sep: '/', listSep: 0},
+}
+
+// lookupPathOS returns the system named name, or nil.
+func lookupPathOS(name string) *pathOS {
+	for _, o := range pathOSes {
+		if o.name == name {
+			return o
+		}
+	}
+	return nil
+}
+
+func (o *pathOS) windows() bool { return o.name == "windows" }
+
+// isSep reports whether c is a path separator. Windows accepts slashes too.
+func (o *pathOS) isSep(c byte) bool {
+	return c == o.sep || o.windows() && c == '/'
+}
+
+// volumeNameLen returns the length of the leading volume name of path on
+// Windows: a drive letter such as C:, a UNC share such as \\host\share, or a
+// device path such as \\.\COM1, \\?\C: or \\.\UNC\host\share.
+func (o *pathOS) volumeNameLen(path string) int {
+	if !o.windows() {
+		return 0
+	}
+	switch {
+	case len(path) >= 2 && path[1] == ':':
+		// A drive letter, which is not checked to be in A-Z.
+		return 2
+	case len(path) == 0 || !o.isSep(path[0]):
+		return 0
+	case o.hasPrefixFold(path, `\\.`) || o.hasPrefixFold(path, `\\?`) || o.hasPrefixFold(path, `\??`):
+		// A device path: \\.\ for local devices, \\?\ or \??\ for root local
+		// devices. The next element is part of the volume name, or the host
+		// and share after UNC.
+		switch {
+		case len(path) == 3:
+			return 3
+		case o.hasPrefixFold(path[4:], `UNC`):
+			return o.validVolumeNameLen(path, o.uncLen(path, len(`\\.\UNC\`)))
+		}
+		_, rest, ok := o.cutPath(path[4:])
+		if !ok {
+			return o.validVolumeNameLen(path, len(path))
+		}
+		return o.validVolumeNameLen(path, len(path)-len(rest)-1)
+	case len(path) >= 2 && o.isSep(path[1]):
+		// A UNC path: two separators, then a host name and a share name.
+		return o.validVolumeNameLen(path, o.uncLen(path, 2))
+	}
+	return 0
+}
+
+// validVolumeNameLen returns n, or 0 if the volume name path[:n] has a ..
+// element.
+func (o *pathOS) validVolumeNameLen(path string, n int) int {
+	for p := path[:n]; p != ""; {
+		var elem string
+		elem, p, _ = o.cutPath(p)
+		if elem == ".." {
+			return 0
+		}
+	}
+	return n
+}
+
+// hasPrefixFold reports whether path starts with prefix, ignoring case and
+// which separators are used, followed by a separator or the end of path.
+func (o *pathOS) hasPrefixFold(path, prefix string) bool {
+	if len(path) < len(prefix) {
+		return false
+	}
+	for i := 0; i < len(prefix); i++ {
+		if o.isSep(prefix[i]) {
+			if !o.isSep(path[i]) {
+				return false
+			}
+		} else if !strings.EqualFold(prefix[i:i+1], path[i:i+1]) {
+			return false
+		}
+	}
+	return len(path) == len(prefix) || o.isSep(path[len(prefix)])
+}
+
+// uncLen returns the length of the volume name of a UNC path, whose host
+// starts at offset prefixLen.
+func (o *pathOS) uncLen(path string, prefixLen int) int {
+	count := 0
+	for i := prefixLen; i < len(path); i++ {
+		if o.isSep(path[i]) {
+			count++
+			if count == 2 {
+				return i
+			}
+		}
+	}
+	return len(path)
+}
+
+// cutPath slices path around its first separator.
+func (o *pathOS) cutPath(path string) (before, after string, found bool) {
+	for i := 0; i < len(path); i++ {
+		if o.isSep(path[i]) {
+			return path[:i], path[i+1:], true
+		}
+	}
+	return path, "", false
+}
+
+func (o *pathOS) isUNC(path string) bool {
+	return o.volumeNameLen(path) > 2
+}
+
+// VolumeName is filepath.VolumeName.
+func (o *pathOS) VolumeName(path string) string {
+	return o.FromSlash(path[:o.volumeNameLen(path)])
+}
+
+// IsAbs is filepath.IsAbs.
+func (o *pathOS) IsAbs(path string) bool {
+	switch o.name {
+	case "windows":
+		l := o.volumeNameLen(path)
+		if l == 0 {
+			return false
+		}
+		// UNC and device paths are absolute.
+		if o.isSep(path[0]) && o.isSep(path[1]) {
+			return true
+		}
+		path = path[l:]
+		return path != "" && o.isSep(path[0])
+	case "plan9":
+		return strings.HasPrefix(path, "/") || strings.HasPrefix(path, "#")
+	}
+	return strings.HasPrefix(path, "/")
+}
+
+// FromSlash is filepath.FromSlash.
+func (o *pathOS) FromSlash(path string) string {
+	if o.sep == '/' {
+		return path
+	}
+	return strings.Replace(path, "/", string(o.sep), -1)
+}
+
+// ToSlash is filepath.ToSlash.
+func (o *pathOS) ToSlash(path string) string {
+	if o.sep == '/' {
+		return path
+	}
+	return strings.Replace(path, string(o.sep), "/", -1)
+}
+
+// Clean is filepath.Clean.
+func (o *pathOS) Clean(path string) string {
+	originalPath := path
+	volLen := o.volumeNameLen(path)
+	path = path[volLen:]
+	if path == "" {
+		if volLen > 1 && o.isSep(originalPath[0]) && o.isSep(originalPath[1]) {
+			// A UNC share.
+			return o.FromSlash(originalPath)
+		}
+		return originalPath + "."
+	}
+	rooted := o.isSep(path[0])
+
+	// Invariants:
+	//	reading from path; r is index of next byte to process.
+	//	writing to out; out.w is index of next byte to write.
+	//	dotdot is index in out where .. must stop, either because it is
+	//	the leading slash or it is a leading ../../.. prefix.
+	n := len(path)
+	out := lazybuf{path: path, volAndPath: originalPath, volLen: volLen}
+	r, dotdot := 0, 0
+	if rooted {
+		out.append(o.sep)
+		r, dotdot = 1, 1
+	}
+
+	for r < n {
+		switch {
+		case o.isSep(path[r]):
+			// Empty path element.
+			r++
+		case path[r] == '.' && (r+1 == n || o.isSep(path[r+1])):
+			// . element
+			r++
+		case path[r] == '.' && path[r+1] == '.' && (r+2 == n || o.isSep(path[r+2])):
+			// .. element: remove to last separator
+			r += 2
+			switch {
+			case out.w > dotdot:
+				// can backtrack
+				out.w--
+				for out.w > dotdot && !o.isSep(out.index(out.w)) {
+					out.w--
+				}
+			case !rooted:
+				// cannot backtrack, but not rooted, so append .. element.
+				if out.w > 0 {
+					out.append(o.sep)
+				}
+				out.append('.')
+				out.append('.')
+				dotdot = out.w
+			}
+		default:
+			// real path element.
+			// add slash if needed
+			if rooted && out.w != 1 || !rooted && out.w != 0 {
+				out.append(o.sep)
+			}
+			// copy element
+			for ; r < n && !o.isSep(path[r]); r++ {
+				out.append(path[r])
+			}
+		}
+	}
+
+	// Turn empty string into "."
+	if out.w == 0 {
+		out.append('.')
+	}
+	if o.windows() {
+		o.postClean(&out)
+	}
+	return o.FromSlash(out.string())
+}
+
+// postClean keeps Clean from turning a relative Windows path into an absolute
+// or rooted one.
+func (o *pathOS) postClean(out *lazybuf) {
+	if out.volLen != 0 || out.buf == nil {
+		return
+	}
+	// A colon in the first element would make a drive letter of it, as in
+	// a\..\c:, so it gets a .\ prefix.
+	for _, c := range out.buf[:out.w] {
+		if o.isSep(c) {
+			break
+		}
+		if c == ':' {
+			out.prepend('.', o.sep)
+			return
+		}
+	}
+	// A leading \??\ would make a root local device path, as in \a\..\??\c:\x.
+	if out.w >= 3 && o.isSep(out.buf[0]) && out.buf[1] == '?' && out.buf[2] == '?' {
+		out.prepend(o.sep, '.')
+	}
+}
+
+// Split is filepath.Split.
+func (o *pathOS) Split(path string) (dir, file string) {
+	vol := o.VolumeName(path)
+	i := len(path) - 1
+	for i >= len(vol) && !o.isSep(path[i]) {
+		i--
+	}
+	return path[:i+1], path[i+1:]
+}
+
+// SplitList is filepath.SplitList. On Windows, separators may be quoted.
+func (o *pathOS) SplitList(path string) []string {
+	if path == "" {
+		return []string{}
+	}
+	if !o.windows() {
+		return strings.Split(path, string(o.listSep))
+	}
+
+	list := []string{}
+	start := 0
+	quo := false
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; {
+		case c == '"':
+			quo = !quo
+		case c == o.listSep && !quo:
+			list = append(list, path[start:i])
+			start = i + 1
+		}
+	}
+	list = append(list, path[start:])
+	for i, s := range list {
+		list[i] = strings.Replace(s, `"`, ``, -1)
+	}
+	return list
+}
+
+// Join is filepath.Join.
+func (o *pathOS) Join(elem ...string) string {
+	for i, e := range elem {
+		if e == "" {
+			continue
+		}
+		if !o.windows() {
+			return o.Clean(strings.Join(elem[i:], string(o.sep)))
+		}
+		return o.joinWindows(elem[i:])
+	}
+	return ""
+}
+
+// joinWindows joins elem, whose first element is not empty, without making a
+// UNC path out of elements that are not, nor a path relative to the current
+// directory of a drive rooted.
+func (o *pathOS) joinWindows(elem []string) string {
+	var b strings.Builder
+	var last byte
+	for _, e := range elem {
+		switch {
+		case b.Len() == 0:
+		case o.isSep(last):
+			for len(e) > 0 && o.isSep(e[0]) {
+				e = e[1:]
+			}
+			// \ and ?? would make a root local device path.
+			if b.Len() == 1 && strings.HasPrefix(e, "??") && (len(e) == 2 || o.isSep(e[2])) {
+				b.WriteString(`.\`)
+			}
+		case last == ':':
+			// C: and f is C:f, relative to the current directory of C:.
+		default:
+			b.WriteByte(o.sep)
+			last = o.sep
+		}
+		if e != "" {
+			b.WriteString(e)
+			last = e[len(e)-1]
+		}
+	}
+	if b.Len() == 0 {
+		return ""
+	}
+	return o.Clean(b.String())
+}
+
+// Base is filepath.Base.
+func (o *pathOS) Base(path string) string {
+	if path == "" {
+		return "."
+	}
+	// Strip trailing separators.
+	for len(path) > 0 && o.isSep(path[len(path)-1]) {
+		path = path[0 : len(path)-1]
+	}
+	path = path[len(o.VolumeName(path)):]
+	i := len(path) - 1
+	for i >= 0 && !o.isSep(path[i]) {
+		i--
+	}
+	if i >= 0 {
+		path = path[i+1:]
+	}
+	if path == "" {
+		return string(o.sep)
+	}
+	return path
+}
+
+// Dir is filepath.Dir.
+func (o *pathOS) Dir(path string) string {
+	vol := o.VolumeName(path)
+	i := len(path) - 1
+	for i >= len(vol) && !o.isSep(path[i]) {
+		i--
+	}
+	dir := o.Clean(path[len(vol) : i+1])
+	if dir == "." && len(vol) > 2 {
+		// A UNC share.
+		return vol
+	}
+	return vol + dir
+}
+
+// Ext is filepath.Ext.
+func (o *pathOS) Ext(path string) string {
+	for i := len(path) - 1; i >= 0 && !o.isSep(path[i]); i-- {
+		if path[i] == '.' {
+			return path[i:]
+		}
+	}
+	return ""
+}
+
+// Rel is filepath.Rel. Windows compares names case-insensitively.
+func (o *pathOS) Rel(basepath, targpath string) (string, error) {
+	baseVol := o.VolumeName(basepath)
+	targVol := o.VolumeName(targpath)
+	base := o.Clean(basepath)
+	targ := o.Clean(targpath)
+	if o.sameWord(targ, base) {
+		return ".", nil
+	}
+	base = base[len(baseVol):]
+	targ = targ[len(targVol):]
+	if base == "." {
+		base = ""
+	} else if base == "" && o.isUNC(baseVol) {
+		// Targets under a \\host\share base are absolute.
+		base = string(o.sep)
+	}
+	// Can't use IsAbs: `\a` and `a` are both relative on Windows.
+	baseSlashed := len(base) > 0 && base[0] == o.sep
+	targSlashed := len(targ) > 0 && targ[0] == o.sep
+	if baseSlashed != targSlashed || !o.sameWord(baseVol, targVol) {
+		return "", errors.New("Rel: can't make " + targpath + " relative to " + basepath)
+	}
+
+	// Position base[b0:bi] and targ[t0:ti] at the first differing elements.
+	bl := len(base)
+	tl := len(targ)
+	var b0, bi, t0, ti int
+	for {
+		for bi < bl && base[bi] != o.sep {
+			bi++
+		}
+		for ti < tl && targ[ti] != o.sep {
+			ti++
+		}
+		if !o.sameWord(targ[t0:ti], base[b0:bi]) {
+			break
+		}
+		if bi < bl {
+			bi++
+		}
+		if ti < tl {
+			ti++
+		}
+		b0 = bi
+		t0 = ti
+	}
+	if base[b0:bi] == ".." {
+		return "", errors.New("Rel: can't make " + targpath + " relative to " + basepath)
+	}
+	if b0 == bl {
+		return targ[t0:], nil
+	}
+
+	// Base elements left: go up before going down.
+	sep := string(o.sep)
+	rel := ".." + strings.Repeat(sep+"..", strings.Count(base[b0:bl], sep))
+	if t0 != tl {
+		rel += sep + targ[t0:]
+	}
+	return o.Clean(rel), nil
+}
+
+func (o *pathOS) sameWord(a, b string) bool {
+	if o.windows() {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+// A lazybuf is a lazily constructed path buffer: it only allocates when the
+// cleaned path differs from the original.
+type lazybuf struct {
+	path       string
+	buf        []byte
+	w          int
+	volAndPath string
+	volLen     int
+}
+
+func (b *lazybuf) index(i int) byte {
+	if b.buf != nil {
+		return b.buf[i]
+	}
+	return b.path[i]
+}
+
+func (b *lazybuf) append(c byte) {
+	if b.buf == nil {
+		if b.w < len(b.path) && b.path[b.w] == c {
+			b.w++
+			return
+		}
+		b.buf = make([]byte, len(b.path))
+		copy(b.buf, b.path[:b.w])
+	}
+	b.buf[b.w] = c
+	b.w++
+}
+
+func (b *lazybuf) prepend(prefix ...byte) {
+	b.buf = append(prefix, b.buf...)
+	b.w += len(prefix)
+}
+
+func (b *lazybuf) string() string {
+	if b.buf == nil {
+		return b.volAndPath[:b.volLen+b.w]
+	}
+	return b.volAndPath[:b.volLen] + string(b.buf[:b.w])
+}
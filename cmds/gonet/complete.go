@@ -0,0 +1,106 @@
+package gonet
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aca/gosh/registry"
+	"github.com/spf13/cobra"
+)
+
+// hostsFile is the hosts database hosts and addresses are completed from,
+// along with the known_hosts file of ssh.
+var hostsFile = "/etc/hosts"
+
+// completeParam completes host names and addresses from the hosts file and
+// the known hosts of ssh, for the parameters taking them.
+func completeParam(p registry.Param, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var hosts, addrs []string
+	if f, err := os.Open(hostsFile); err == nil {
+		hosts, addrs = parseHosts(f)
+		f.Close()
+	}
+
+	switch p.Name {
+	case "host", "name":
+		if home, err := os.UserHomeDir(); err == nil {
+			if f, err := os.Open(filepath.Join(home, ".ssh", "known_hosts")); err == nil {
+				known, knownAddrs := parseKnownHosts(f)
+				hosts = append(append(hosts, known...), knownAddrs...)
+				f.Close()
+			}
+		}
+		return uniq(append(hosts, addrs...)), cobra.ShellCompDirectiveNoFileComp
+	case "addr":
+		return uniq(addrs), cobra.ShellCompDirectiveNoFileComp
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// parseHosts returns the host names and addresses listed in a hosts file.
+func parseHosts(r io.Reader) (hosts, addrs []string) {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := sc.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 || net.ParseIP(fields[0]) == nil {
+			continue
+		}
+		addrs = append(addrs, fields[0])
+		hosts = append(hosts, fields[1:]...)
+	}
+	return hosts, addrs
+}
+
+// parseKnownHosts returns the host names and addresses listed in a
+// known_hosts file of ssh. Hashed entries are skipped, and the port of
+// [host]:port entries is dropped.
+func parseKnownHosts(r io.Reader) (hosts, addrs []string) {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fields := strings.Fields(sc.Text())
+		if len(fields) > 0 && strings.HasPrefix(fields[0], "@") {
+			// A marker, such as @cert-authority.
+			fields = fields[1:]
+		}
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "|") {
+			continue
+		}
+
+		for _, h := range strings.Split(fields[0], ",") {
+			if strings.HasPrefix(h, "[") {
+				if i := strings.Index(h, "]:"); i >= 0 {
+					h = h[1:i]
+				}
+			}
+			switch {
+			case h == "" || strings.ContainsAny(h, "*?!"):
+			case net.ParseIP(h) != nil:
+				addrs = append(addrs, h)
+			default:
+				hosts = append(hosts, h)
+			}
+		}
+	}
+	return hosts, addrs
+}
+
+// uniq returns the distinct elements of l, sorted.
+func uniq(l []string) []string {
+	sort.Strings(l)
+	out := l[:0]
+	for i, s := range l {
+		if i == 0 || s != l[i-1] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
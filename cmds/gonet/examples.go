@@ -0,0 +1,28 @@
+package gonet
+
+import "github.com/aca/gosh/utils"
+
+// examples are the examples of the commands, by name. Lookups are only given
+// IP addresses and invalid names, which are resolved without querying DNS.
+var examples = map[string][]utils.Example{
+	"joinhostport": {
+		{Args: []string{"::1", "80"}, Output: "[::1]:80"},
+	},
+	"lookupaddr": {
+		{Args: []string{"not-an-ip"}, Exit: utils.ExitLookup},
+	},
+	"lookupcname": {
+		{Args: []string{""}, Exit: utils.ExitLookup},
+	},
+	"lookuphost": {
+		{Args: []string{"192.0.2.1"}, Output: "192.0.2.1\n"},
+	},
+	"lookuptxt": {
+		{Args: []string{""}, Exit: utils.ExitLookup},
+	},
+	"parsecidr": {
+		{Args: []string{"192.0.2.1/24"}, Output: "192.0.2.1\n192.0.2.0/24\n"},
+		{Args: []string{"192.0.2.1/24", "-o", "template={{.ip}} in {{.ipnet}}"}, Output: "192.0.2.1 in 192.0.2.0/24\n"},
+		{Args: []string{"192.0.2.1"}, Exit: utils.ExitParse},
+	},
+}
@@ -0,0 +1,60 @@
+package gonet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aca/gosh/utils"
+	"github.com/aca/gosh/utils/cmdtest"
+)
+
+// Lookups are given IP addresses and invalid names, which are resolved without
+// querying DNS.
+func TestCommands(t *testing.T) {
+	cmdtest.Run(t, Cmd, []utils.Example{
+		{Args: []string{"joinhostport", "::1", "80"}, Output: "[::1]:80"},
+		{Args: []string{"joinhostport", "80"}, Stdin: "example.com", Output: "example.com:80"},
+		{Args: []string{"parsecidr", "192.0.2.1/24", "-o", "json"}, Output: `{"ip":"192.0.2.1","ipnet":{"IP":"192.0.2.0","Mask":"ffffff00"}}` + "\n"},
+		{Args: []string{"parsecidr", "-l", "-o", "csv"}, Stdin: "192.0.2.1/24\n10.0.0.1/8\n", Output: "ip,ipnet\n192.0.2.1,192.0.2.0/24\n10.0.0.1,10.0.0.0/8\n"},
+		{Args: []string{"parsecidr", "192.0.2.1"}, Exit: utils.ExitParse},
+		{Args: []string{"lookuphost", "192.0.2.1", "-o", "json"}, Output: `["192.0.2.1"]` + "\n"},
+		{Args: []string{"lookupaddr", "not-an-ip"}, Exit: utils.ExitLookup},
+		{Args: []string{"lookuptxt", ""}, Exit: utils.ExitLookup},
+		{Args: []string{"joinhostport"}, Exit: utils.ExitUsage},
+	})
+}
+
+func TestExamples(t *testing.T) {
+	cmdtest.Examples(t, Cmd)
+}
+
+func TestParseHosts(t *testing.T) {
+	hosts, addrs := parseHosts(strings.NewReader(`# comment
+127.0.0.1	localhost
+::1	localhost ip6-localhost # loopback
+192.0.2.1 example.test
+not-an-ip	bogus
+`))
+	if got, want := strings.Join(hosts, " "), "localhost localhost ip6-localhost example.test"; got != want {
+		t.Errorf("hosts: got %q, want %q", got, want)
+	}
+	if got, want := strings.Join(addrs, " "), "127.0.0.1 ::1 192.0.2.1"; got != want {
+		t.Errorf("addrs: got %q, want %q", got, want)
+	}
+}
+
+func TestParseKnownHosts(t *testing.T) {
+	hosts, addrs := parseKnownHosts(strings.NewReader(`example.test,192.0.2.1 ssh-ed25519 AAAA
+[git.example.test]:2222 ssh-rsa AAAA
+|1|c2FsdA==|aGFzaA== ssh-ed25519 AAAA
+@cert-authority *.example.test ssh-rsa AAAA
+@revoked other.example.test ssh-rsa AAAA
+# comment
+`))
+	if got, want := strings.Join(hosts, " "), "example.test git.example.test other.example.test"; got != want {
+		t.Errorf("hosts: got %q, want %q", got, want)
+	}
+	if got, want := strings.Join(addrs, " "), "192.0.2.1"; got != want {
+		t.Errorf("addrs: got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,142 @@
+package gostrings
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/aca/gosh/registry"
+	"github.com/aca/gosh/utils"
+	"github.com/spf13/cobra"
+)
+
+// specialCases are the languages of --special-case, with their case mappings.
+var specialCases = map[string]unicode.SpecialCase{
+	"az": unicode.AzeriCase,
+	"tr": unicode.TurkishCase,
+}
+
+// specialFuncs are the functions taking --special-case, other than title.
+var specialFuncs = map[string]func(c unicode.SpecialCase, s string) string{
+	"tolower": strings.ToLowerSpecial,
+	"totitle": strings.ToTitleSpecial,
+	"toupper": strings.ToUpperSpecial,
+}
+
+// titleModes are the values of --title-mode: titling every word, or the first
+// one only.
+var titleModes = []string{"words", "first"}
+
+// addCaseFlags adds --special-case to c, the command running f, if f maps
+// case, and --title-mode if it is title.
+//
+// Title doesn't run strings.Title, which is deprecated: it titles words as
+// strings.Title does except that an apostrophe within a word doesn't start a
+// new one, so "don't" becomes "Don't".
+func addCaseFlags(c *cobra.Command, f *registry.Func) {
+	fn, ok := specialFuncs[f.Name]
+	if !ok && f.Name != "title" {
+		return
+	}
+	c.Flags().Var(newChoiceValue("", specialCaseNames()...), "special-case", "map case with the rules of a language: "+strings.Join(specialCaseNames(), ", "))
+	c.RegisterFlagCompletionFunc("special-case", completeChoices(specialCaseNames()...))
+	if f.Name == "title" {
+		c.Long += "\n\nUnlike strings.Title, an apostrophe within a word doesn't start a new one."
+		c.Flags().Var(newChoiceValue(titleModes[0], titleModes...), "title-mode", "title "+strings.Join(titleModes, " or "))
+		c.RegisterFlagCompletionFunc("title-mode", completeChoices(titleModes...))
+	}
+
+	c.RunE = func(cmd *cobra.Command, args []string) error {
+		special := specialCases[cmd.Flag("special-case").Value.String()]
+		mapCase := func(s string) string { return fn(special, s) }
+		if f.Name == "title" {
+			first := cmd.Flag("title-mode").Value.String() == "first"
+			mapCase = func(s string) string { return title(special, s, first) }
+		}
+		return utils.FuncRunE(f.WithFunc(func(args []string) ([]interface{}, error) {
+			return []interface{}{mapCase(args[0])}, nil
+		}))(cmd, args)
+	}
+}
+
+// title maps the first letter of each word of s, or of the first one only, to
+// title case with c.
+func title(c unicode.SpecialCase, s string, first bool) string {
+	inWord, titled := false, false
+	return strings.Map(func(r rune) rune {
+		wasInWord := inWord
+		inWord = !isSeparator(r) || wasInWord && (r == '\'' || r == '’')
+		if inWord && !wasInWord && !(first && titled) {
+			titled = true
+			return c.ToTitle(r)
+		}
+		return r
+	}, s)
+}
+
+// isSeparator reports whether r separates words, as for strings.Title.
+func isSeparator(r rune) bool {
+	// ASCII alphanumerics and underscore are not separators.
+	if r <= 0x7F {
+		switch {
+		case '0' <= r && r <= '9':
+			return false
+		case 'a' <= r && r <= 'z':
+			return false
+		case 'A' <= r && r <= 'Z':
+			return false
+		case r == '_':
+			return false
+		}
+		return true
+	}
+	// Letters and digits are not separators.
+	if unicode.IsLetter(r) || unicode.IsDigit(r) {
+		return false
+	}
+	// Otherwise, all we can do for now is treat spaces as separators.
+	return unicode.IsSpace(r)
+}
+
+func specialCaseNames() []string {
+	names := make([]string, 0, len(specialCases))
+	for name := range specialCases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// choiceValue is the value of a flag taking one of choices, or empty.
+type choiceValue struct {
+	value   string
+	choices []string
+}
+
+func newChoiceValue(value string, choices ...string) *choiceValue {
+	return &choiceValue{value: value, choices: choices}
+}
+
+func (v *choiceValue) String() string { return v.value }
+func (v *choiceValue) Type() string   { return "string" }
+
+func (v *choiceValue) Set(s string) error {
+	for _, c := range v.choices {
+		if s == c {
+			v.value = s
+			return nil
+		}
+	}
+	if s == "" {
+		v.value = s
+		return nil
+	}
+	return fmt.Errorf("expected one of %s", strings.Join(v.choices, ", "))
+}
+
+func completeChoices(choices ...string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return choices, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,206 @@
+package gostrings
+
+import "github.com/aca/gosh/utils"
+
+// examples are the examples of the commands, by name.
+var examples = map[string][]utils.Example{
+	"case": {
+		{Args: []string{"snake", "parseHTTPRequestID"}, Output: "parse_http_request_id"},
+		{Args: []string{"pascal", "user_id"}, Output: "UserID"},
+		{Args: []string{"camel", "-l"}, Stdin: "API_KEY\nsha256-sum\n", Output: "apiKey\nsha256Sum\n"},
+		{Args: []string{"title", "x"}, Exit: utils.ExitUsage},
+	},
+	"clone": {
+		{Args: []string{"gopher"}, Output: "gopher"},
+	},
+	"compare": {
+		{Args: []string{"a", "b"}, Output: "-1"},
+		{Args: []string{"b", "b"}, Output: "0"},
+	},
+	"contains": {
+		{Args: []string{"seafood", "foo"}},
+		{Args: []string{"seafood", "bar"}, Exit: utils.ExitFalse},
+	},
+	"containsany": {
+		{Args: []string{"failure", "ui"}},
+		{Args: []string{"foo", ""}, Exit: utils.ExitFalse},
+	},
+	"containsfunc": {
+		{Args: []string{"hello, 世界", "IsDigit"}, Exit: utils.ExitFalse},
+		{Args: []string{"r2d2", "IsDigit"}},
+	},
+	"containsrune": {
+		{Args: []string{"aardvark", "v"}},
+		{Args: []string{"timeout", "z"}, Exit: utils.ExitFalse},
+	},
+	"count": {
+		{Args: []string{"cheese", "e"}, Output: "3"},
+	},
+	"cut": {
+		{Args: []string{"key=value", "="}, Output: "key\nvalue\ntrue\n"},
+		{Args: []string{"key=value", "=", "-o", "json"}, Output: `{"before":"key","after":"value","found":true}` + "\n"},
+		{Args: []string{"-l", ":", "-o", "tsv"}, Stdin: "a:1\nb\n", Output: "before\tafter\tfound\na\t1\ttrue\nb\t\tfalse\n"},
+	},
+	"cutlast": {
+		{Args: []string{"a.tar.gz", ".", "-o", "json"}, Output: `{"before":"a.tar","after":"gz","found":true}` + "\n"},
+	},
+	"cutprefix": {
+		{Args: []string{"Gopher", "Go", "-o", "json"}, Output: `{"after":"pher","found":true}` + "\n"},
+	},
+	"cutsuffix": {
+		{Args: []string{"main.go", ".go", "-o", "shell"}, Output: "before=main\nfound=true\n"},
+	},
+	"equalfold": {
+		{Args: []string{"Go", "GO"}},
+		{Args: []string{"Go", "Gopher"}, Exit: utils.ExitFalse},
+	},
+	"fields": {
+		{Args: []string{"  foo bar  baz   "}, Output: "foo\nbar\nbaz\n"},
+	},
+	"fieldsfunc": {
+		{Args: []string{"  foo1;bar2,baz3...", "IsPunct"}, Output: "  foo1\nbar2\nbaz3\n"},
+	},
+	"hasprefix": {
+		{Args: []string{"golang", "go"}},
+		{Args: []string{"golang", "C"}, Exit: utils.ExitFalse},
+		{Args: []string{"go", "-l"}, Stdin: "gopher\nrust\n", Output: "true\nfalse\n"},
+	},
+	"hassuffix": {
+		{Args: []string{"main.go", ".go"}},
+		{Args: []string{"main.go", ".rs"}, Exit: utils.ExitFalse},
+	},
+	"index": {
+		{Args: []string{"chicken", "ken"}, Output: "4"},
+		{Args: []string{"chicken", "dmr"}, Output: "-1"},
+	},
+	"indexany": {
+		{Args: []string{"golang", "ly"}, Output: "2"},
+	},
+	"indexbyte": {
+		{Args: []string{"golang", "l"}, Output: "2"},
+	},
+	"indexfunc": {
+		{Args: []string{"Hello, 世界", "IsDigit"}, Output: "-1"},
+		{Args: []string{"abc1", "IsDigit"}, Output: "3"},
+		{Args: []string{"Hello, 世界", "--func", "Han"}, Output: "7"},
+	},
+	"indexrune": {
+		{Args: []string{"chicken", "k"}, Output: "4"},
+		{Args: []string{"chicken", "kk"}, Exit: utils.ExitParse},
+	},
+	"join": {
+		{Args: []string{","}, Stdin: "a\nb\nc\n", Output: "a,b,c"},
+		{Args: []string{" ", "--json", "--quote", "shell"}, Stdin: `["a b","c"]`, Output: "'a b' c"},
+		{Args: []string{"|", "--prefix", "<", "--suffix", ">"}, Stdin: "a\nb\n", Output: "<a>|<b>"},
+		{Args: []string{",", "--json"}, Stdin: `{"a":1}`, Exit: utils.ExitParse},
+	},
+	"lastindex": {
+		{Args: []string{"go gopher", "go"}, Output: "3"},
+	},
+	"lastindexany": {
+		{Args: []string{"go gopher", "go"}, Output: "4"},
+	},
+	"lastindexbyte": {
+		{Args: []string{"go gopher", "o"}, Output: "4"},
+	},
+	"lastindexfunc": {
+		{Args: []string{"go123", "IsDigit"}, Output: "4"},
+	},
+	"map": {
+		{Args: []string{"ToUpper", "gopher"}, Output: "GOPHER"},
+		{Args: []string{"IsDigit", "gopher"}, Exit: utils.ExitParse},
+		{Args: []string{"--func", "!IsPrint", "--replace", "_", "-l"}, Stdin: "a\tb c\n", Output: "a_b c\n"},
+		{Args: []string{"--func", "Han", "--replace", "?", "hello, 世界"}, Output: "hello, ??"},
+	},
+	"pad": {
+		{Args: []string{"--width", "6", "-l"}, Stdin: "名前\nabc\n", Output: "名前  \nabc   \n"},
+		{Args: []string{"--width", "6", "--align", "center", "世界"}, Output: " 世界 "},
+		{Args: []string{"--align", "right", "a"}, Exit: utils.ExitUsage},
+	},
+	"repeat": {
+		{Args: []string{"na", "2"}, Output: "nana"},
+		{Args: []string{"na", "x"}, Exit: utils.ExitParse},
+	},
+	"replace": {
+		{Args: []string{"oink oink oink", "k", "ky", "2"}, Output: "oinky oinky oink"},
+	},
+	"replacer": {
+		{Args: []string{"a", "b", "b", "a"}, Stdin: "abba", Output: "baab"},
+		{Args: []string{"<", "&lt;", ">", "&gt;"}, Stdin: "<p>\n</p>\n", Output: "&lt;p&gt;\n&lt;/p&gt;\n"},
+		{Args: []string{"a"}, Exit: utils.ExitUsage},
+	},
+	"replaceall": {
+		{Args: []string{"oink oink oink", "oink", "moo"}, Output: "moo moo moo"},
+	},
+	"split": {
+		{Args: []string{"a,b,c", ","}, Output: "a\nb\nc\n"},
+		{Args: []string{",", "-o", "json"}, Stdin: "a,b", Output: `["a","b"]` + "\n"},
+	},
+	"splitafter": {
+		{Args: []string{"a,b,c", ","}, Output: "a,\nb,\nc\n"},
+	},
+	"splitaftern": {
+		{Args: []string{"a,b,c", ",", "2"}, Output: "a,\nb,c\n"},
+	},
+	"splitn": {
+		{Args: []string{"a,b,c", ",", "2"}, Output: "a\nb,c\n"},
+	},
+	"title": {
+		{Args: []string{"her royal highness"}, Output: "Her Royal Highness"},
+		{Args: []string{"don't panic"}, Output: "Don't Panic"},
+		{Args: []string{"--title-mode", "first", "her royal highness"}, Output: "Her royal highness"},
+	},
+	"tolower": {
+		{Args: []string{"Gopher"}, Output: "gopher"},
+		{Args: []string{"--special-case", "tr", "DİYARBAKIR"}, Output: "diyarbakır"},
+	},
+	"totitle": {
+		{Args: []string{"loud noises"}, Output: "LOUD NOISES"},
+		{Args: []string{"--special-case", "az", "ilıq"}, Output: "İLIQ"},
+	},
+	"toupper": {
+		{Args: []string{"Gopher"}, Output: "GOPHER"},
+		{Args: []string{"-l"}, Stdin: "a\nb\n", Output: "A\nB\n"},
+		{Args: []string{"--special-case", "tr", "istanbul"}, Output: "İSTANBUL"},
+	},
+	"tovalidutf8": {
+		{Args: []string{"a\xffb", "?"}, Output: "a?b"},
+	},
+	"trim": {
+		{Args: []string{"¡¡¡Hello, Gophers!!!", "!¡"}, Output: "Hello, Gophers"},
+	},
+	"trimfunc": {
+		{Args: []string{"123abc456", "IsDigit"}, Output: "abc"},
+		{Args: []string{"IsDigit", "-l"}, Stdin: "1a2\n3b4\n", Output: "a\nb\n"},
+		{Args: []string{"--func", "!IsLetter", "-l"}, Stdin: "¡¡¡Hello, Gophers!!!\n", Output: "Hello, Gophers\n"},
+	},
+	"trimleft": {
+		{Args: []string{"¡¡¡Hello, Gophers!!!", "!¡"}, Output: "Hello, Gophers!!!"},
+	},
+	"trimleftfunc": {
+		{Args: []string{"123abc456", "IsDigit"}, Output: "abc456"},
+	},
+	"trimprefix": {
+		{Args: []string{"¡¡¡Hello, Gophers!!!", "¡¡¡Hello, "}, Output: "Gophers!!!"},
+	},
+	"trimright": {
+		{Args: []string{"¡¡¡Hello, Gophers!!!", "!¡"}, Output: "¡¡¡Hello, Gophers"},
+	},
+	"trimrightfunc": {
+		{Args: []string{"123abc456", "IsDigit"}, Output: "123abc"},
+	},
+	"trimspace": {
+		{Stdin: " \t\n Hello, Gophers \n\t\r\n", Output: "Hello, Gophers"},
+	},
+	"trimsuffix": {
+		{Args: []string{"main.go", ".go"}, Output: "main"},
+	},
+	"truncate": {
+		{Args: []string{"--width", "6", "-l"}, Stdin: "こんにちは\nhello world\nhi\n", Output: "こん…\nhello…\nhi\n"},
+		{Args: []string{"--width", "8", "--ellipsis", "...", "Hello, 世界!"}, Output: "Hello..."},
+	},
+	"width": {
+		{Args: []string{"Hello, 世界"}, Output: "11"},
+		{Args: []string{"-l"}, Stdin: "e\u0301\n😀\n", Output: "1\n2\n"},
+	},
+}
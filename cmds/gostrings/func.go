@@ -0,0 +1,119 @@
+package gostrings
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aca/gosh/registry"
+	"github.com/aca/gosh/utils"
+	"github.com/spf13/cobra"
+)
+
+const predicateLong = `
+
+The rune predicate is the name of a function of package unicode, such as
+IsDigit, or of one of its range tables: a category such as Lu, a script such
+as Han or Cyrillic, or a property such as White_Space. A leading ! negates it.`
+
+const funcLong = predicateLong + `
+With --func, the predicate is given by the flag and the string may be read
+from stdin.`
+
+const mapLong = predicateLong + `
+
+With --func, runes matching the rune predicate are deleted with --delete or
+replaced by the rune of --replace, and other runes are kept. The string may
+then be read from stdin.`
+
+// addFuncFlag adds --func to c, the command running f, if f takes a rune
+// predicate, or is map.
+func addFuncFlag(c *cobra.Command, f *registry.Func) {
+	if f.Name == "map" {
+		addMapFlags(c)
+		return
+	}
+	i := len(f.Params) - 1
+	if i < 0 || f.Params[i].Type != "func(rune) bool" {
+		return
+	}
+	c.Long += funcLong
+	c.Flags().String("func", "", "rune predicate, in place of the "+f.Params[i].Name+" argument")
+	c.RegisterFlagCompletionFunc("func", completePredicates)
+
+	args, runE := c.Args, c.RunE
+	c.Args = func(cmd *cobra.Command, a []string) error {
+		if !cmd.Flags().Changed("func") {
+			return args(cmd, a)
+		}
+		return cobra.RangeArgs(i-1, i)(cmd, a)
+	}
+	c.RunE = func(cmd *cobra.Command, a []string) error {
+		if cmd.Flags().Changed("func") {
+			// The predicate is the last parameter: the string read from
+			// stdin, if any, is still inserted before it.
+			a = append(a, cmd.Flag("func").Value.String())
+		}
+		return runE(cmd, a)
+	}
+}
+
+// addMapFlags adds --func, --delete and --replace to c, the command running
+// map, to delete or replace the runes matching a rune predicate.
+func addMapFlags(c *cobra.Command) {
+	c.Long += mapLong
+	c.Flags().String("func", "", "rune predicate matching the runes to delete or replace, in place of the mapping argument")
+	c.Flags().Bool("delete", false, "delete the runes matching --func")
+	c.Flags().String("replace", "", "replace the runes matching --func by this rune")
+	c.RegisterFlagCompletionFunc("func", completePredicates)
+
+	args, runE := c.Args, c.RunE
+	c.Args = func(cmd *cobra.Command, a []string) error {
+		fs := cmd.Flags()
+		if !fs.Changed("func") {
+			if fs.Changed("delete") || fs.Changed("replace") {
+				return errors.New("--delete and --replace require --func")
+			}
+			return args(cmd, a)
+		}
+		if fs.Changed("delete") == fs.Changed("replace") {
+			return errors.New("--func requires one of --delete or --replace")
+		}
+		if r := cmd.Flag("replace").Value.String(); fs.Changed("replace") && utf8.RuneCountInString(r) != 1 {
+			return fmt.Errorf("--replace takes a single rune, got %q", r)
+		}
+		return cobra.MaximumNArgs(1)(cmd, a)
+	}
+	c.RunE = func(cmd *cobra.Command, a []string) error {
+		if !cmd.Flags().Changed("func") {
+			return runE(cmd, a)
+		}
+		match, err := registry.ParsePredicate(cmd.Flag("func").Value.String())
+		if err != nil {
+			return err
+		}
+		repl := rune(-1)
+		if cmd.Flags().Changed("replace") {
+			repl, _ = utf8.DecodeRuneInString(cmd.Flag("replace").Value.String())
+		}
+		return utils.Run(1, func(cmd *cobra.Command, args []string, p *utils.Printer) error {
+			return p.Print(strings.Map(func(r rune) rune {
+				if match(r) {
+					return repl
+				}
+				return r
+			}, args[0]))
+		})(cmd, a)
+	}
+}
+
+func completePredicates(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names := registry.PredicateNames()
+	if strings.HasPrefix(toComplete, "!") {
+		for i, name := range names {
+			names[i] = "!" + name
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
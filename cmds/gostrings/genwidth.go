@@ -0,0 +1,148 @@
+//go:build ignore
+// +build ignore
+
+// genwidth generates the table of wide runes of the width commands from
+// EastAsianWidth.txt of the Unicode Character Database. As the file is not
+// part of the repository, it isn't run by go generate but by hand, in
+// cmds/gostrings:
+//
+//	curl -O https://www.unicode.org/Public/17.0.0/ucd/EastAsianWidth.txt
+//	go run genwidth.go -i EastAsianWidth.txt
+//
+// Runes of East Asian width W (wide) or F (fullwidth) take two columns.
+//
+// The file must be of the Unicode version of package unicode, which the width
+// commands take the categories of runes from: 17.0.0 with Go 1.27.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type runeRange struct {
+	lo, hi rune
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("genwidth: ")
+
+	in := flag.String("i", "EastAsianWidth.txt", "EastAsianWidth.txt of the Unicode Character Database")
+	out := flag.String("o", "width_table.go", "output file")
+	flag.Parse()
+
+	version, ranges, err := readWide(*in)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if version != unicode.Version {
+		log.Fatalf("%s is of Unicode %s, want %s as package unicode", *in, version, unicode.Version)
+	}
+	src, err := generate(version, ranges)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readWide returns the Unicode version of the file at path, from its first
+// line, and its ranges of wide and fullwidth runes, merged.
+func readWide(path string) (string, []runeRange, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+
+	var (
+		version string
+		ranges  []runeRange
+	)
+	sc := bufio.NewScanner(f)
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		if n == 1 {
+			// # EastAsianWidth-15.1.0.txt
+			version = strings.TrimSuffix(strings.TrimPrefix(line, "# EastAsianWidth-"), ".txt")
+		}
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Split(line, ";")
+		if len(fields) != 2 {
+			continue
+		}
+		if w := strings.TrimSpace(fields[1]); w != "W" && w != "F" {
+			continue
+		}
+
+		r, err := parseRange(strings.TrimSpace(fields[0]))
+		if err != nil {
+			return "", nil, fmt.Errorf("%s:%d: %v", path, n, err)
+		}
+		if l := len(ranges); l > 0 && ranges[l-1].hi+1 == r.lo {
+			ranges[l-1].hi = r.hi
+			continue
+		}
+		ranges = append(ranges, r)
+	}
+	return version, ranges, sc.Err()
+}
+
+// parseRange parses a code point, such as 3000, or a range, such as
+// 1100..115F.
+func parseRange(s string) (runeRange, error) {
+	lo, hi := s, s
+	if i := strings.Index(s, ".."); i >= 0 {
+		lo, hi = s[:i], s[i+2:]
+	}
+	l, err := strconv.ParseUint(lo, 16, 32)
+	if err != nil {
+		return runeRange{}, err
+	}
+	h, err := strconv.ParseUint(hi, 16, 32)
+	if err != nil {
+		return runeRange{}, err
+	}
+	return runeRange{rune(l), rune(h)}, nil
+}
+
+func generate(version string, ranges []runeRange) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by genwidth.go from EastAsianWidth.txt; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package gostrings\n\n")
+	fmt.Fprintf(&buf, "import \"unicode\"\n\n")
+	fmt.Fprintf(&buf, "// wideTable holds the runes of East Asian width W or F of Unicode %s.\n", version)
+	fmt.Fprintf(&buf, "var wideTable = &unicode.RangeTable{\n")
+	fmt.Fprintf(&buf, "R16: []unicode.Range16{\n")
+	for _, r := range ranges {
+		if r.hi <= 0xFFFF {
+			fmt.Fprintf(&buf, "{Lo: 0x%04x, Hi: 0x%04x, Stride: 1},\n", r.lo, r.hi)
+		}
+	}
+	fmt.Fprintf(&buf, "},\n")
+	fmt.Fprintf(&buf, "R32: []unicode.Range32{\n")
+	for _, r := range ranges {
+		if r.hi > 0xFFFF {
+			if r.lo <= 0xFFFF {
+				return nil, fmt.Errorf("range %04X..%04X spans the 16-bit boundary", r.lo, r.hi)
+			}
+			fmt.Fprintf(&buf, "{Lo: 0x%x, Hi: 0x%x, Stride: 1},\n", r.lo, r.hi)
+		}
+	}
+	fmt.Fprintf(&buf, "},\n")
+	fmt.Fprintf(&buf, "}\n")
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,250 @@
+package gostrings
+
+import (
+	"go/importer"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/aca/gosh/registry"
+	"github.com/aca/gosh/utils"
+	"github.com/aca/gosh/utils/cmdtest"
+)
+
+func TestCommands(t *testing.T) {
+	cmdtest.Run(t, Cmd, []utils.Example{
+		{Args: []string{"toupper", "abc"}, Output: "ABC"},
+		{Args: []string{"toupper"}, Stdin: "abc", Output: "ABC"},
+		{Args: []string{"toupper", "-l"}, Stdin: "a\nb\n", Output: "A\nB\n"},
+		{Args: []string{"split", "-z", ","}, Stdin: "a,b", Output: "a\x00b\x00"},
+		{Args: []string{"split", "a,b", ",", "-o", "json"}, Output: `["a","b"]` + "\n"},
+		{Args: []string{"split", "a,b", ",", "-o", "csv"}, Output: "a,b\n"},
+		{Args: []string{"hasprefix", "golang", "go"}},
+		{Args: []string{"hasprefix", "golang", "C"}, Exit: utils.ExitFalse},
+		{Args: []string{"hasprefix", "-l", "go"}, Stdin: "gopher\nrust\n", Output: "true\nfalse\n"},
+		{Args: []string{"compare", "b", "a"}, Output: "1"},
+		{Args: []string{"trimspace"}, Stdin: " a \n", Output: "a"},
+		{Args: []string{"split", "-e", `\t`}, Stdin: "a\tb", Output: "a\nb\n"},
+		{Args: []string{"repeat", "a", "x"}, Exit: utils.ExitParse},
+		{Args: []string{"indexrune", "abc", "bc"}, Exit: utils.ExitParse},
+		{Args: []string{"repeat"}, Exit: utils.ExitUsage},
+		{Args: []string{"toupper", "a", "-o", "xml"}, Exit: utils.ExitUsage},
+		{Args: []string{"toupper", "--bogus"}, Exit: utils.ExitUsage},
+		{Args: []string{"join", ","}, Stdin: "", Output: ""},
+		{Args: []string{"join", ",", "-z"}, Stdin: "a\x00b\n\x00", Output: "a,b\n\x00"},
+		{Args: []string{"join", ", ", "--json", "--quote", "go"}, Stdin: `["a\"", 1, null]`, Output: `"a\"", "1", "null"`},
+		{Args: []string{"join", ",", "--quote", "json"}, Stdin: "<a>\n", Output: `"<a>"`},
+		{Args: []string{"join", ",", "--quote", "csv"}, Exit: utils.ExitUsage},
+		{Args: []string{"join"}, Exit: utils.ExitUsage},
+		{Args: []string{"replacer", "-e", "a\nb", "c"}, Stdin: "a\nb\n", Output: "c\n"},
+		{Args: []string{"replacer", "", "-"}, Stdin: "ab\ncd", Output: "-a-b-\n-c-d-"},
+		{Args: []string{"replacer", "-l", "a", "b", "-o", "json"}, Stdin: "a\nab\n", Output: "\"b\"\n\"bb\"\n"},
+		{Args: []string{"replacer", "-z", "a", "b"}, Stdin: "a\x00a", Output: "b\x00b\x00"},
+		{Args: []string{"replacer", "a", "b", "-o", "json"}, Stdin: "a\n", Output: "\"b\\n\"\n"},
+		{Args: []string{"replacer"}, Exit: utils.ExitUsage},
+		{Args: []string{"replacer", "--file", "/nonexistent/pairs.tsv"}, Exit: utils.ExitFailure},
+		{Args: []string{"trimfunc", "--func", "Cyrillic", "-l"}, Stdin: "Привет, world\n", Output: ", world\n"},
+		{Args: []string{"trimleftfunc", "--func", "!nd", "abc123"}, Output: "123"},
+		{Args: []string{"fieldsfunc", "--func", "white_space"}, Stdin: "a b\tc", Output: "a\nb\nc\n"},
+		{Args: []string{"lastindexfunc", "a1b2c", "!IsDigit"}, Output: "4"},
+		{Args: []string{"containsfunc", "--func", "Greek", "αβγ"}},
+		{Args: []string{"trimfunc", "--func", "IsDigit", "a", "IsDigit"}, Exit: utils.ExitUsage},
+		{Args: []string{"trimfunc", "--func", "!Bogus", "a"}, Exit: utils.ExitParse},
+		{Args: []string{"map", "--func", "IsControl", "--replace", " ", "-z"}, Stdin: "a\tb\x00", Output: "a b\x00"},
+		{Args: []string{"map", "--func", "IsControl", "a"}, Exit: utils.ExitUsage},
+		{Args: []string{"map", "--func", "IsControl", "--delete", "--replace", " ", "a"}, Exit: utils.ExitUsage},
+		{Args: []string{"map", "--replace", " ", "ToUpper", "a"}, Exit: utils.ExitUsage},
+		{Args: []string{"case", "camel", "userIDs"}, Output: "userIDs"},
+		{Args: []string{"case", "kebab", "XMLHttpRequest"}, Output: "xml-http-request"},
+		{Args: []string{"case", "pascal", "utf8_decode"}, Output: "UTF8Decode"},
+		{Args: []string{"case", "screaming", "Émile Zola"}, Output: "ÉMILE_ZOLA"},
+		{Args: []string{"case", "snake", "-z"}, Stdin: "newURLsList\x00数据ID\x00", Output: "new_urls_list\x00数据_id\x00"},
+		{Args: []string{"case", "snake", "a", "b"}, Exit: utils.ExitUsage},
+		{Args: []string{"width", "-z"}, Stdin: "ｆｕｌｌ\x00한글\x00\u1100\u1161\x00", Output: "8\x004\x002\x00"},
+		{Args: []string{"pad", "--width", "5", "--align", "right", "-l"}, Stdin: "😀\n", Output: "   😀\n"},
+		{Args: []string{"pad", "--width", "1", "abc"}, Output: "abc"},
+		{Args: []string{"pad", "--width", "-1", "a"}, Exit: utils.ExitUsage},
+		{Args: []string{"truncate", "--width", "3", "世界世界"}, Output: "世…"},
+		{Args: []string{"truncate", "--width", "4", "--ellipsis", "", "a\u0301b\u0301c\u0301de"}, Output: "a\u0301b\u0301c\u0301d"},
+		{Args: []string{"truncate", "--width", "1", "--ellipsis", "...", "abc"}, Output: "."},
+		{Args: []string{"truncate", "--width", "2", "--ellipsis", "…", "ab"}, Output: "ab"},
+		{Args: []string{"truncate", "--width", "3", "👨\u200d👩\u200d👧x"}, Output: "👨\u200d👩\u200d👧x"},
+		{Args: []string{"truncate", "--width", "3", "👨\u200d👩\u200d👧xy"}, Output: "👨\u200d👩\u200d👧…"},
+		{Args: []string{"truncate", "--width", "2", "👨\u200d👩\u200d👧xy"}, Output: "…"},
+		{Args: []string{"width", "👨\u200d👩\u200d👧"}, Output: "2"},
+		{Args: []string{"width", "🫷"}, Output: "2"},
+		{Args: []string{"toupper", "--special-case", "tr", "-l"}, Stdin: "i\nı\n", Output: "İ\nI\n"},
+		{Args: []string{"toupper", "--special-case", "de", "a"}, Exit: utils.ExitUsage},
+		{Args: []string{"title", "'quoted' o'neil’s rock-n-roll"}, Output: "'Quoted' O'neil’s Rock-N-Roll"},
+		{Args: []string{"title", "--title-mode", "first", "--special-case", "tr", " istanbul"}, Output: " İstanbul"},
+		{Args: []string{"title", "--title-mode", "last", "a"}, Exit: utils.ExitUsage},
+	})
+}
+
+func TestExamples(t *testing.T) {
+	cmdtest.Examples(t, Cmd)
+}
+
+// covered maps the exported functions of package strings that have no
+// command of their own to the command covering them, or to "" if they have no
+// use on the command line.
+var covered = map[string]string{
+	"FieldsFuncSeq":  "fieldsfunc",
+	"FieldsSeq":      "fields",
+	"Join":           "join",
+	"Lines":          "splitafter",
+	"NewReader":      "",
+	"NewReplacer":    "replacer",
+	"SplitAfterSeq":  "splitafter",
+	"SplitSeq":       "split",
+	"ToLowerSpecial": "tolower",
+	"ToTitleSpecial": "totitle",
+	"ToUpperSpecial": "toupper",
+}
+
+// Every function of package strings is covered by a command, so that the
+// commands keep up with new versions of Go.
+func TestParity(t *testing.T) {
+	pkg, err := importer.ForCompiler(token.NewFileSet(), "source", nil).Import("strings")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range pkg.Scope().Names() {
+		if _, ok := pkg.Scope().Lookup(name).(*types.Func); !ok || !token.IsExported(name) {
+			continue
+		}
+		cmdName, ok := covered[name]
+		if !ok {
+			cmdName = strings.ToLower(name)
+		}
+		if cmdName == "" {
+			continue
+		}
+		if c, _, err := Cmd.Find([]string{cmdName}); err != nil || c == Cmd {
+			t.Errorf("strings.%s is not covered: no command %s", name, cmdName)
+		}
+	}
+}
+
+func TestParsePairs(t *testing.T) {
+	tests := []struct {
+		file  string
+		pairs []string
+		err   bool
+	}{
+		{file: "a\tb\n\nc\t\n", pairs: []string{"a", "b", "c", ""}},
+		{file: "a\tb\tc\n", pairs: []string{"a", "b\tc"}},
+		{file: "a\n", err: true},
+		{file: ` {"z": "a", "a": "z", "": "-"}`, pairs: []string{"z", "a", "a", "z", "", "-"}},
+		{file: `{"a": 1}`, err: true},
+		{file: `{"a": "b"`, err: true},
+	}
+	for _, tt := range tests {
+		pairs, err := parsePairs([]byte(tt.file))
+		if (err != nil) != tt.err || strings.Join(pairs, "|") != strings.Join(tt.pairs, "|") {
+			t.Errorf("parsePairs(%q) = %q, %v; want %q, error %v", tt.file, pairs, err, tt.pairs, tt.err)
+		}
+	}
+}
+
+func TestIdentWords(t *testing.T) {
+	tests := []struct {
+		s     string
+		words []string
+	}{
+		{s: "", words: nil},
+		{s: "fooBar", words: []string{"foo", "Bar"}},
+		{s: "HTTPServer", words: []string{"HTTP", "Server"}},
+		{s: "userIDs", words: []string{"user", "IDs"}},
+		{s: "IDsrc", words: []string{"I", "Dsrc"}},
+		{s: "UTF8Decode", words: []string{"UTF8", "Decode"}},
+		{s: "sha256_sum", words: []string{"sha256", "sum"}},
+		{s: "--a--b--", words: []string{"a", "b"}},
+		{s: "ÉmileZola", words: []string{"Émile", "Zola"}},
+		{s: "数据ID", words: []string{"数据", "ID"}},
+	}
+	for _, tt := range tests {
+		if words := identWords(tt.s); strings.Join(words, "|") != strings.Join(tt.words, "|") {
+			t.Errorf("identWords(%q) = %q, want %q", tt.s, words, tt.words)
+		}
+	}
+}
+
+func TestRuneWidth(t *testing.T) {
+	tests := []struct {
+		r     rune
+		width int
+	}{
+		{'a', 1},
+		{'\t', 0},
+		{'\u0301', 0}, // combining acute accent
+		{'\u200d', 0}, // zero width joiner
+		{'é', 1},
+		{'世', 2},
+		{'ｆ', 2},
+		{'ｶ', 1}, // halfwidth katakana
+		{'😀', 2},
+		{'🫷', 2},      // Unicode 15
+		{'\u3000', 2}, // ideographic space
+		{'\U00020000', 2},
+		{'\U0002fffd', 2}, // unassigned, wide by default
+	}
+	for _, tt := range tests {
+		if w := runeWidth(tt.r); w != tt.width {
+			t.Errorf("runeWidth(%U) = %d, want %d", tt.r, w, tt.width)
+		}
+	}
+}
+
+func TestStringWidth(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+	}{
+		{"", 0},
+		{"a\u0301b", 2},
+		{"👨\u200d👩\u200d👧", 2},
+		{"👩🏽\u200d💻x", 3},
+		{"🏽", 2}, // a skin tone alone
+		{"❤\ufe0f", 2},
+		{"\u200d", 0},
+		{"a\u200d", 1},
+	}
+	for _, tt := range tests {
+		if w := stringWidth(tt.s); w != tt.width {
+			t.Errorf("stringWidth(%q) = %d, want %d", tt.s, w, tt.width)
+		}
+	}
+}
+
+// Every command has a function in the registry, hand-written ones included.
+func TestRegistry(t *testing.T) {
+	for _, c := range Cmd.Commands() {
+		if c.Name() == "completion" || c.Name() == "help" {
+			continue
+		}
+		if registry.Lookup("gostrings", c.Name()) == nil {
+			t.Errorf("%s is not in the registry", c.Name())
+		}
+	}
+
+	tests := []struct {
+		name   string
+		args   []string
+		result interface{}
+	}{
+		{"join", []string{"-", "a", "b"}, "a-b"},
+		{"replacer", []string{"abba", "a", "b", "b", "a"}, "baab"},
+		{"case", []string{"user_id", "pascal"}, "UserID"},
+		{"pad", []string{"世", "4", "center"}, " 世 "},
+		{"truncate", []string{"世界", "3", "…"}, "世…"},
+		{"width", []string{"世界"}, 4},
+	}
+	for _, tt := range tests {
+		results, err := registry.Lookup("gostrings", tt.name).Invoke(tt.args)
+		if err != nil || len(results) != 1 || results[0] != tt.result {
+			t.Errorf("%s %q = %v, %v; want %v", tt.name, tt.args, results, err, tt.result)
+		}
+	}
+}
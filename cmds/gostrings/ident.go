@@ -0,0 +1,191 @@
+package gostrings
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/aca/gosh/registry"
+	"github.com/aca/gosh/utils"
+	"github.com/spf13/cobra"
+)
+
+const caseLong = `Case converts the identifier s to a case style:
+
+  camel       userID
+  pascal      UserID
+  snake       user_id
+  kebab       user-id
+  screaming   USER_ID
+
+s is split into words on runes other than letters and digits, before an upper
+case letter following a lower case one, and before the last upper case letter
+of a run followed by a lower case one, so that HTTPServer is HTTP and Server.
+Digits belong to the word they follow.
+
+Camel and pascal cases write Go initialisms such as ID, URL or HTTP in upper
+case, as Go identifiers do, but for the first word of camel case.`
+
+// caseStyles are the styles of case, with the function joining the words of
+// an identifier in the style.
+var caseStyles = map[string]func(words []string) string{
+	"camel": func(words []string) string {
+		if len(words) == 0 {
+			return ""
+		}
+		return strings.ToLower(words[0]) + pascalCase(words[1:])
+	},
+	"pascal": pascalCase,
+	"snake": func(words []string) string {
+		return strings.ToLower(strings.Join(words, "_"))
+	},
+	"kebab": func(words []string) string {
+		return strings.ToLower(strings.Join(words, "-"))
+	},
+	"screaming": func(words []string) string {
+		return strings.ToUpper(strings.Join(words, "_"))
+	},
+}
+
+// initialisms are the words Go identifiers write in upper case, as listed by
+// golint.
+var initialisms = map[string]bool{
+	"ACL": true, "API": true, "ASCII": true, "CPU": true, "CSS": true,
+	"DNS": true, "EOF": true, "GUID": true, "HTML": true, "HTTP": true,
+	"HTTPS": true, "ID": true, "IP": true, "JSON": true, "LHS": true,
+	"QPS": true, "RAM": true, "RHS": true, "RPC": true, "SLA": true,
+	"SMTP": true, "SQL": true, "SSH": true, "TCP": true, "TLS": true,
+	"TTL": true, "UDP": true, "UI": true, "UID": true, "UUID": true,
+	"URI": true, "URL": true, "UTF8": true, "VM": true, "XML": true,
+	"XMPP": true, "XSRF": true, "XSS": true,
+}
+
+// caseFunc is case in the registry. The identifier comes first, as it is the
+// argument read from stdin.
+var caseFunc = (&registry.Func{
+	Name:      "case",
+	Package:   "github.com/aca/gosh/cmds/gostrings",
+	Signature: "func Case(s, style string) string",
+	Doc:       caseLong,
+	Params:    []registry.Param{{Name: "s", Type: "string"}, {Name: "style", Type: "string"}},
+	Results:   []registry.Param{{Name: "string", Type: "string"}},
+}).WithFunc(func(args []string) ([]interface{}, error) {
+	style := caseStyles[args[1]]
+	if style == nil {
+		return nil, &registry.ArgError{Type: "case style", Arg: args[1]}
+	}
+	return []interface{}{style(identWords(args[0]))}, nil
+})
+
+// newCaseCommand returns the case command, converting identifiers between
+// case styles, which has no counterpart in package strings.
+func newCaseCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "case style [s]",
+		Short:                 "convert an identifier to " + strings.Join(caseStyleNames(), ", ") + " case",
+		Long:                  caseLong,
+		DisableFlagsInUseLine: true,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.RangeArgs(1, 2)(cmd, args); err != nil {
+				return err
+			}
+			if caseStyles[args[0]] == nil {
+				return fmt.Errorf("unknown case style %q, expected one of %s", args[0], strings.Join(caseStyleNames(), ", "))
+			}
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return caseStyleNames(), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Run reads the identifier from stdin as the first argument, so
+			// it goes before the style.
+			if len(args) == 2 {
+				args = []string{args[1], args[0]}
+			}
+			return utils.Run(2, func(cmd *cobra.Command, args []string, p *utils.Printer) error {
+				return utils.RunFunc(caseFunc, args, p)
+			})(cmd, args)
+		},
+	}
+	return cmd
+}
+
+// identWords splits the identifier s into words.
+func identWords(s string) []string {
+	var words []string
+	start := -1
+	var prev rune
+	for i, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, s[start:i])
+			}
+			start, prev = -1, 0
+			continue
+		}
+		switch {
+		case start < 0:
+			start = i
+		case unicode.IsUpper(r) && !unicode.IsUpper(prev):
+			// fooBar, sha256Sum
+			words = append(words, s[start:i])
+			start = i
+		case unicode.IsLower(r) && unicode.IsUpper(prev) && !pluralInitialism(s[start:], i-start, r):
+			// HTTPServer: the last upper case letter starts a word.
+			if j := i - utf8.RuneLen(prev); j > start {
+				words = append(words, s[start:j])
+				start = j
+			}
+		}
+		prev = r
+	}
+	if start >= 0 {
+		words = append(words, s[start:])
+	}
+	return words
+}
+
+// pluralInitialism reports whether the lower case letter r at offset i of
+// word, following a run of upper case letters, is the s of a plural
+// initialism ending the word, as in userIDs.
+func pluralInitialism(word string, i int, r rune) bool {
+	if r != 's' || !initialisms[word[:i]] {
+		return false
+	}
+	next, _ := utf8.DecodeRuneInString(word[i+1:])
+	return i+1 == len(word) || !unicode.IsLower(next)
+}
+
+// pascalCase joins words, capitalized or in upper case for initialisms.
+func pascalCase(words []string) string {
+	var b strings.Builder
+	for _, w := range words {
+		upper := strings.ToUpper(w)
+		switch {
+		case initialisms[upper]:
+			b.WriteString(upper)
+		case strings.HasSuffix(upper, "S") && initialisms[upper[:len(upper)-1]]:
+			b.WriteString(upper[:len(upper)-1] + "s")
+		default:
+			r, n := utf8.DecodeRuneInString(w)
+			b.WriteRune(unicode.ToTitle(r))
+			b.WriteString(strings.ToLower(w[n:]))
+		}
+	}
+	return b.String()
+}
+
+func caseStyleNames() []string {
+	names := make([]string, 0, len(caseStyles))
+	for name := range caseStyles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,158 @@
+package gostrings
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/aca/gosh/registry"
+	"github.com/aca/gosh/utils"
+	"github.com/spf13/cobra"
+)
+
+const joinLong = `func Join(elems []string, sep string) string
+
+Join concatenates the records read from stdin, separated by sep. Records are
+lines, NUL-terminated with -z, or the elements of a JSON array with --json, so
+that join reverses split:
+
+  $ gostrings split a,b,c , | gostrings join ,
+  a,b,c
+
+Each record may be quoted, then wrapped with --prefix and --suffix.`
+
+// quoteStyles are the values of --quote, with the function quoting records.
+var quoteStyles = map[string]func(s string) string{
+	"go":    strconv.Quote,
+	"json":  quoteJSON,
+	"shell": utils.ShellQuote,
+}
+
+// joinFunc is join in the registry. Its elements are the arguments after sep,
+// so that it joins the values of a pipeline, and its signature is written in
+// that order rather than as strings.Join's.
+var joinFunc = (&registry.Func{
+	Name:      "join",
+	Package:   "strings",
+	Signature: "func Join(sep string, elems ...string) string",
+	Doc:       "Join concatenates elems to create a single string. The separator string sep\nis placed between elements in the resulting string.",
+	Params:    []registry.Param{{Name: "sep", Type: "string"}, {Name: "elems", Type: "...string"}},
+	Results:   []registry.Param{{Name: "string", Type: "string"}},
+	Variadic:  true,
+}).WithFunc(func(args []string) ([]interface{}, error) {
+	return []interface{}{strings.Join(args[1:], args[0])}, nil
+})
+
+// newJoinCommand returns the join command, which is not generated as its
+// elements are read from stdin rather than given as arguments.
+func newJoinCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "join sep",
+		Short:                 "func Join(elems []string, sep string) string",
+		Long:                  joinLong,
+		Args:                  cobra.ExactArgs(1),
+		DisableFlagsInUseLine: true,
+		RunE: utils.Run(1, func(cmd *cobra.Command, args []string, p *utils.Printer) error {
+			elems, err := readRecords(cmd)
+			if err != nil {
+				return err
+			}
+			if err := wrapRecords(cmd, elems); err != nil {
+				return err
+			}
+			return p.Print(strings.Join(elems, args[0]))
+		}),
+	}
+	cmd.Flags().Bool("json", false, "read a JSON array rather than lines")
+	cmd.Flags().String("prefix", "", "write prefix before each record")
+	cmd.Flags().String("suffix", "", "write suffix after each record")
+	cmd.Flags().Var(newChoiceValue("", quoteStyleNames()...), "quote", "quote each record as a string literal of "+strings.Join(quoteStyleNames(), ", "))
+	cmd.RegisterFlagCompletionFunc("quote", completeChoices(quoteStyleNames()...))
+	return cmd
+}
+
+// readRecords returns the records of stdin.
+func readRecords(cmd *cobra.Command) ([]string, error) {
+	isJSON, err := cmd.Flags().GetBool("json")
+	if err != nil {
+		return nil, err
+	}
+	null, err := cmd.Flags().GetBool("null")
+	if err != nil {
+		return nil, err
+	}
+
+	if isJSON {
+		b, err := ioutil.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return nil, err
+		}
+		return jsonRecords(b)
+	}
+
+	elems := []string{}
+	sc := utils.NewRecordScanner(cmd.InOrStdin(), null)
+	for sc.Scan() {
+		elems = append(elems, sc.Text())
+	}
+	return elems, sc.Err()
+}
+
+// jsonRecords returns the elements of a JSON array. Strings are records as
+// is, other values as JSON.
+func jsonRecords(b []byte) ([]string, error) {
+	var l []interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&l); err != nil {
+		return nil, err
+	}
+	elems := make([]string, len(l))
+	for i, v := range l {
+		if s, ok := v.(string); ok {
+			elems[i] = s
+			continue
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = string(b)
+	}
+	return elems, nil
+}
+
+// wrapRecords quotes and wraps elems as set by the flags of cmd.
+func wrapRecords(cmd *cobra.Command, elems []string) error {
+	prefix, err := cmd.Flags().GetString("prefix")
+	if err != nil {
+		return err
+	}
+	suffix, err := cmd.Flags().GetString("suffix")
+	if err != nil {
+		return err
+	}
+	quote := quoteStyles[cmd.Flag("quote").Value.String()]
+
+	for i, e := range elems {
+		if quote != nil {
+			e = quote(e)
+		}
+		elems[i] = prefix + e + suffix
+	}
+	return nil
+}
+
+func quoteJSON(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func quoteStyleNames() []string {
+	return []string{"go", "json", "shell"}
+}
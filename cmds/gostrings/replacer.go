@@ -0,0 +1,213 @@
+package gostrings
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/aca/gosh/registry"
+	"github.com/aca/gosh/utils"
+	"github.com/spf13/cobra"
+)
+
+const replacerLong = `func NewReplacer(oldnew ...string) *Replacer
+
+Replacer replaces every old string of the old, new pairs with its new string
+in stdin, in a single pass: replacements are not replaced again, so pairs can
+swap strings. Pairs are compared in order, those of --file after those of the
+arguments.
+
+--file reads pairs from a file, either TSV lines of old and new separated by a
+tab, or a JSON object mapping old strings to new ones.
+
+Stdin is streamed line by line, unless an old string holds a newline. In line
+mode, each line is replaced on its own.`
+
+// replacerFunc is replacer in the registry, replacing its first argument with
+// the old new pairs that follow.
+var replacerFunc = (&registry.Func{
+	Name:          "replacer",
+	Package:       "strings",
+	Signature:     "func (*Replacer) Replace(s string) string",
+	Doc:           "Replace returns a copy of s with all replacements performed by the Replacer\nof the old, new pairs, in a single pass.",
+	Params:        []registry.Param{{Name: "s", Type: "string"}, {Name: "oldnew", Type: "...string"}},
+	Results:       []registry.Param{{Name: "string", Type: "string"}},
+	Variadic:      true,
+	VariadicStdin: true,
+}).WithFunc(func(args []string) ([]interface{}, error) {
+	if len(args)%2 != 1 {
+		return nil, fmt.Errorf("replacer takes old new pairs, got %d argument(s)", len(args)-1)
+	}
+	return []interface{}{strings.NewReplacer(args[1:]...).Replace(args[0])}, nil
+})
+
+// newReplacerCommand returns the replacer command, which is not generated as
+// it builds a Replacer rather than calling a function returning a string.
+func newReplacerCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "replacer [old new]...",
+		Short:                 "func (*Replacer) Replace(s string) string",
+		Long:                  replacerLong,
+		DisableFlagsInUseLine: true,
+		Args: func(cmd *cobra.Command, args []string) error {
+			file, err := cmd.Flags().GetString("file")
+			if err != nil {
+				return err
+			}
+			if len(args)%2 != 0 {
+				return fmt.Errorf("replacer takes old new pairs, got %d argument(s)", len(args))
+			}
+			if len(args) == 0 && file == "" {
+				return errors.New("replacer takes old new pairs or --file")
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lines, err := cmd.Flags().GetBool("lines")
+			if err != nil {
+				return err
+			}
+			null, err := cmd.Flags().GetBool("null")
+			if err != nil {
+				return err
+			}
+
+			// The Replacer is built once, for every record. Whole is set if
+			// stdin can't be replaced line by line: an old string spans lines,
+			// or is empty and so matches at the start and end of every line.
+			var (
+				r     *strings.Replacer
+				whole bool
+			)
+			replacer := func(cmd *cobra.Command, pairs []string) (*strings.Replacer, error) {
+				if r != nil {
+					return r, nil
+				}
+				pairs, err := withFilePairs(cmd, pairs)
+				if err != nil {
+					return nil, err
+				}
+				for i := 0; i < len(pairs); i += 2 {
+					whole = whole || strings.Contains(pairs[i], "\n") || pairs[i] == ""
+				}
+				r = strings.NewReplacer(pairs...)
+				return r, nil
+			}
+
+			if lines || null {
+				return utils.Run(len(args)+1, func(cmd *cobra.Command, args []string, p *utils.Printer) error {
+					r, err := replacer(cmd, args[1:])
+					if err != nil {
+						return err
+					}
+					return p.Print(r.Replace(args[0]))
+				})(cmd, args)
+			}
+			return utils.Run(len(args), func(cmd *cobra.Command, args []string, p *utils.Printer) error {
+				r, err := replacer(cmd, args)
+				if err != nil {
+					return err
+				}
+				return replaceStdin(cmd, r, whole, p)
+			})(cmd, args)
+		},
+	}
+	cmd.Flags().StringP("file", "f", "", "read old new pairs from a TSV or JSON file")
+	cmd.MarkFlagFilename("file", "tsv", "json")
+	return cmd
+}
+
+// withFilePairs returns pairs followed by the pairs of --file.
+func withFilePairs(cmd *cobra.Command, pairs []string) ([]string, error) {
+	file, err := cmd.Flags().GetString("file")
+	if err != nil || file == "" {
+		return pairs, err
+	}
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	filePairs, err := parsePairs(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	return append(append([]string{}, pairs...), filePairs...), nil
+}
+
+// parsePairs returns the old new pairs of a TSV or JSON mapping file.
+func parsePairs(b []byte) ([]string, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) {
+		return jsonPairs(b)
+	}
+
+	var pairs []string
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; sc.Scan(); n++ {
+		line := sc.Text()
+		if line == "" {
+			continue
+		}
+		i := strings.IndexByte(line, '\t')
+		if i < 0 {
+			return nil, fmt.Errorf("line %d: %w", n, &registry.ArgError{Type: "TSV pair", Arg: line})
+		}
+		pairs = append(pairs, line[:i], line[i+1:])
+	}
+	return pairs, sc.Err()
+}
+
+// jsonPairs returns the pairs of a JSON object of strings, in order.
+func jsonPairs(b []byte) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	var pairs []string
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value string
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, key.(string), value)
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return pairs, nil
+}
+
+// replaceStdin replaces stdin with r, streaming it line by line to the output
+// of p unless p can't stream or whole is set.
+func replaceStdin(cmd *cobra.Command, r *strings.Replacer, whole bool, p *utils.Printer) error {
+	w, ok := p.Stream()
+	if !ok || whole {
+		b, err := ioutil.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+		return p.Print(r.Replace(string(b)))
+	}
+
+	br := bufio.NewReader(cmd.InOrStdin())
+	for {
+		line, err := br.ReadString('\n')
+		if _, werr := r.WriteString(w, line); werr != nil {
+			return werr
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package gostrings
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/aca/gosh/registry"
+	"github.com/aca/gosh/utils"
+	"github.com/spf13/cobra"
+)
+
+const widthLong = `
+
+The width of a string is the number of terminal columns it takes, the sum of
+the widths of its runes: two for wide and fullwidth East Asian runes, such as
+CJK ideographs and most emoji, zero for control characters, combining marks
+and format characters such as the zero width joiner, and one for the others.
+Emoji joined by zero width joiners, or with a skin tone, take the width of the
+first one, and a rune followed by the emoji variation selector U+FE0F takes
+two columns.`
+
+// The functions of width, pad and truncate in the registry, whose flags are
+// arguments after s.
+var (
+	widthFunc = (&registry.Func{
+		Name:      "width",
+		Package:   "github.com/aca/gosh/cmds/gostrings",
+		Signature: "func Width(s string) int",
+		Doc:       "Width returns the display width of s." + widthLong,
+		Params:    []registry.Param{{Name: "s", Type: "string"}},
+		Results:   []registry.Param{{Name: "int", Type: "int"}},
+	}).WithFunc(func(args []string) ([]interface{}, error) {
+		return []interface{}{stringWidth(args[0])}, nil
+	})
+
+	padFunc = (&registry.Func{
+		Name:      "pad",
+		Package:   "github.com/aca/gosh/cmds/gostrings",
+		Signature: "func Pad(s string, width int, align string) string",
+		Doc:       padLong,
+		Params:    []registry.Param{{Name: "s", Type: "string"}, {Name: "width", Type: "int"}, {Name: "align", Type: "string"}},
+		Results:   []registry.Param{{Name: "string", Type: "string"}},
+	}).WithFunc(func(args []string) ([]interface{}, error) {
+		width, err := parseWidth(args[1])
+		if err != nil {
+			return nil, err
+		}
+		if !isAlignment(args[2]) {
+			return nil, &registry.ArgError{Type: "alignment", Arg: args[2]}
+		}
+		return []interface{}{pad(args[0], width, args[2])}, nil
+	})
+
+	truncateFunc = (&registry.Func{
+		Name:      "truncate",
+		Package:   "github.com/aca/gosh/cmds/gostrings",
+		Signature: "func Truncate(s string, width int, ellipsis string) string",
+		Doc:       truncateLong,
+		Params:    []registry.Param{{Name: "s", Type: "string"}, {Name: "width", Type: "int"}, {Name: "ellipsis", Type: "string"}},
+		Results:   []registry.Param{{Name: "string", Type: "string"}},
+	}).WithFunc(func(args []string) ([]interface{}, error) {
+		width, err := parseWidth(args[1])
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{truncate(args[0], width, args[2])}, nil
+	})
+)
+
+// parseWidth converts a width argument, which must not be negative.
+func parseWidth(s string) (int, error) {
+	width, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, err
+	}
+	if width < 0 {
+		return 0, &registry.ArgError{Type: "width", Arg: s}
+	}
+	return width, nil
+}
+
+func isAlignment(s string) bool {
+	for _, a := range alignments {
+		if s == a {
+			return true
+		}
+	}
+	return false
+}
+
+const padLong = `Pad pads s with spaces up to the display width of --width, on the right, the
+left or both sides for --align left, right or center. Wider strings are
+returned as is.` + widthLong
+
+const truncateLong = `Truncate truncates s to the display width of --width, ending it with
+--ellipsis. Runes are never split, so a wide rune that doesn't fit is
+dropped and the result may be one column narrower.` + widthLong
+
+// alignments are the values of --align.
+var alignments = []string{"left", "right", "center"}
+
+// newWidthCommand returns the width command, printing the display width of
+// s.
+func newWidthCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:                   "width [s]",
+		Short:                 "display width of s in terminal columns",
+		Long:                  "Width returns the display width of s." + widthLong,
+		Args:                  cobra.MaximumNArgs(1),
+		DisableFlagsInUseLine: true,
+		RunE: utils.Run(1, func(cmd *cobra.Command, args []string, p *utils.Printer) error {
+			return utils.RunFunc(widthFunc, args, p)
+		}),
+	}
+}
+
+// newPadCommand returns the pad command, padding s with spaces to a display
+// width.
+func newPadCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "pad [s]",
+		Short:                 "pad s with spaces to a display width",
+		Long:                  padLong,
+		Args:                  widthArgs,
+		DisableFlagsInUseLine: true,
+		RunE: utils.Run(1, func(cmd *cobra.Command, args []string, p *utils.Printer) error {
+			width, err := cmd.Flags().GetInt("width")
+			if err != nil {
+				return err
+			}
+			return p.Print(pad(args[0], width, cmd.Flag("align").Value.String()))
+		}),
+	}
+	addWidthFlag(cmd)
+	cmd.Flags().Var(newChoiceValue(alignments[0], alignments...), "align", "align s "+strings.Join(alignments, ", "))
+	cmd.RegisterFlagCompletionFunc("align", completeChoices(alignments...))
+	return cmd
+}
+
+// newTruncateCommand returns the truncate command, truncating s to a display
+// width.
+func newTruncateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "truncate [s]",
+		Short:                 "truncate s to a display width",
+		Long:                  truncateLong,
+		Args:                  widthArgs,
+		DisableFlagsInUseLine: true,
+		RunE: utils.Run(1, func(cmd *cobra.Command, args []string, p *utils.Printer) error {
+			width, err := cmd.Flags().GetInt("width")
+			if err != nil {
+				return err
+			}
+			ellipsis, err := cmd.Flags().GetString("ellipsis")
+			if err != nil {
+				return err
+			}
+			return p.Print(truncate(args[0], width, ellipsis))
+		}),
+	}
+	addWidthFlag(cmd)
+	cmd.Flags().String("ellipsis", "…", "end truncated strings with ellipsis")
+	return cmd
+}
+
+func addWidthFlag(cmd *cobra.Command) {
+	cmd.Flags().Int("width", 0, "display width, in columns")
+	cmd.MarkFlagRequired("width")
+}
+
+// widthArgs checks the arguments of the commands taking --width.
+func widthArgs(cmd *cobra.Command, args []string) error {
+	width, err := cmd.Flags().GetInt("width")
+	if err != nil {
+		return err
+	}
+	if width < 0 {
+		return fmt.Errorf("--width must not be negative, got %d", width)
+	}
+	return cobra.MaximumNArgs(1)(cmd, args)
+}
+
+// runeWidth returns the number of columns r takes on a terminal.
+func runeWidth(r rune) int {
+	switch {
+	case unicode.IsControl(r), unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case 0x1160 <= r && r <= 0x11FF:
+		// Hangul medial vowels and final consonants combine with the
+		// preceding initial consonant.
+		return 0
+	case unicode.Is(wideTable, r):
+		return 2
+	}
+	return 1
+}
+
+// Runes joining clusters.
+const (
+	zeroWidthJoiner = '\u200d'
+	emojiSelector   = '\ufe0f' // VS16
+)
+
+// cluster returns the length in bytes of the first cluster of s, the runes
+// displayed as one, and the number of columns it takes. A cluster is a rune
+// followed by the zero width runes combining with it, emoji skin tones, and
+// the runes following zero width joiners, as in the emoji 👨‍👩‍👧.
+func cluster(s string) (n, width int) {
+	r, n := utf8.DecodeRuneInString(s)
+	width = runeWidth(r)
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		switch {
+		case r == zeroWidthJoiner:
+			// The next rune, if any, is joined.
+			_, next := utf8.DecodeRuneInString(s[n+size:])
+			size += next
+		case r == emojiSelector:
+			if width == 1 {
+				width = 2
+			}
+		case 0x1F3FB <= r && r <= 0x1F3FF && width == 2:
+			// A skin tone modifying the emoji before it.
+		case runeWidth(r) != 0:
+			return n, width
+		}
+		n += size
+	}
+	return n, width
+}
+
+// stringWidth returns the number of columns s takes on a terminal.
+func stringWidth(s string) int {
+	width := 0
+	for s != "" {
+		n, w := cluster(s)
+		width += w
+		s = s[n:]
+	}
+	return width
+}
+
+// pad pads s with spaces to width columns, aligned left, right or center.
+func pad(s string, width int, align string) string {
+	n := width - stringWidth(s)
+	if n <= 0 {
+		return s
+	}
+	switch align {
+	case "right":
+		return strings.Repeat(" ", n) + s
+	case "center":
+		return strings.Repeat(" ", n/2) + s + strings.Repeat(" ", n-n/2)
+	}
+	return s + strings.Repeat(" ", n)
+}
+
+// truncate truncates s to width columns, ending it with ellipsis if it is
+// truncated. The ellipsis is truncated too if it is wider than width.
+func truncate(s string, width int, ellipsis string) string {
+	if stringWidth(s) <= width {
+		return s
+	}
+	ew := stringWidth(ellipsis)
+	if ew > width {
+		return truncate(ellipsis, width, "")
+	}
+
+	// Cut between clusters, not to leave half of one.
+	w := 0
+	for i := 0; i < len(s); {
+		n, cw := cluster(s[i:])
+		if w += cw; w > width-ew {
+			return s[:i] + ellipsis
+		}
+		i += n
+	}
+	return s
+}
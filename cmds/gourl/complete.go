@@ -0,0 +1,17 @@
+package gourl
+
+import (
+	"github.com/aca/gosh/registry"
+	"github.com/spf13/cobra"
+)
+
+// schemes are offered as the start of URLs to parse.
+var schemes = []string{"http://", "https://", "file://", "ftp://", "ws://", "wss://", "mailto:", "data:"}
+
+// completeParam completes URLs with their scheme, leaving the rest to type.
+func completeParam(p registry.Param, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if p.Name != "rawURL" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return schemes, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
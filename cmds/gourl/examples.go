@@ -0,0 +1,29 @@
+package gourl
+
+import "github.com/aca/gosh/utils"
+
+// examples are the examples of the commands, by name. The fields of a parsed
+// url.URL vary with the Go version, so the example of parse prints some of
+// them through a template rather than the whole struct.
+var examples = map[string][]utils.Example{
+	"parse": {
+		{Args: []string{"https://example.com:8080/x?q=1", "-o", "template={{.Hostname}} {{.Port}} {{.Query | json}}"}, Output: `example.com 8080 {"q":["1"]}` + "\n"},
+		{Args: []string{"%zz"}, Exit: utils.ExitParse},
+	},
+	"parserequesturi": {
+		{Args: []string{"relative/path"}, Exit: utils.ExitParse},
+	},
+	"pathescape": {
+		{Args: []string{"a b/c"}, Output: "a%20b%2Fc"},
+	},
+	"pathunescape": {
+		{Args: []string{"a%20b%2Fc"}, Output: "a b/c"},
+		{Args: []string{"%zz"}, Exit: utils.ExitParse},
+	},
+	"queryescape": {
+		{Args: []string{"a b&c"}, Output: "a+b%26c"},
+	},
+	"queryunescape": {
+		{Args: []string{"a+b%26c"}, Output: "a b&c"},
+	},
+}
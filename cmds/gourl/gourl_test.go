@@ -0,0 +1,37 @@
+package gourl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aca/gosh/utils"
+	"github.com/aca/gosh/utils/cmdtest"
+)
+
+func TestCommands(t *testing.T) {
+	cmdtest.Run(t, Cmd, []utils.Example{
+		{Args: []string{"queryescape", "a b&c"}, Output: "a+b%26c"},
+		{Args: []string{"queryunescape"}, Stdin: "a+b%26c", Output: "a b&c"},
+		{Args: []string{"pathescape", "-l"}, Stdin: "a b\nc/d\n", Output: "a%20b\nc%2Fd\n"},
+		{Args: []string{"pathunescape", "%zz"}, Exit: utils.ExitParse},
+		{Args: []string{"parse", "%zz"}, Exit: utils.ExitParse},
+		{Args: []string{"parserequesturi", "relative"}, Exit: utils.ExitParse},
+		{Args: []string{"queryescape", "a", "b"}, Exit: utils.ExitUsage},
+	})
+}
+
+func TestParse(t *testing.T) {
+	out, _, err := utils.Example{Args: []string{"parse", "https://example.com/a?b=c", "-o", "json"}}.Run(Cmd, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{`"Scheme":"https"`, `"Host":"example.com"`, `"Path":"/a"`, `"RawQuery":"b=c"`} {
+		if !strings.Contains(out, field) {
+			t.Errorf("parse output %s lacks %s", out, field)
+		}
+	}
+}
+
+func TestExamples(t *testing.T) {
+	cmdtest.Examples(t, Cmd)
+}
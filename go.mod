@@ -2,4 +2,7 @@ module github.com/aca/gosh
 
 go 1.14
 
-require github.com/spf13/cobra v1.1.1
+require (
+	github.com/spf13/cobra v1.1.1
+	github.com/spf13/pflag v1.0.5
+)
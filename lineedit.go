@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// errInterrupt is returned by readLine when the line is cancelled by ^C.
+var errInterrupt = errors.New("interrupt")
+
+// lineEditor reads lines from a terminal in raw mode, with cursor movement,
+// history and tab completion.
+type lineEditor struct {
+	fd     int
+	in     *bufio.Reader
+	out    io.Writer
+	prompt string
+
+	history []string
+
+	// complete returns the completions of the last word of line, and whether
+	// a space should follow a single completion.
+	complete func(line string) (completions []string, space bool)
+
+	buf []rune
+	pos int
+}
+
+// readLine reads a line, returning io.EOF on ^D at an empty line and
+// errInterrupt on ^C.
+func (e *lineEditor) readLine() (string, error) {
+	restore, err := makeRaw(e.fd)
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+
+	e.buf, e.pos = nil, 0
+	hist := len(e.history)
+	e.refresh()
+
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		switch r {
+		case '\r', '\n':
+			fmt.Fprint(e.out, "\n")
+			line := string(e.buf)
+			if strings.TrimSpace(line) != "" {
+				e.history = append(e.history, line)
+			}
+			return line, nil
+		case 3: // ^C
+			fmt.Fprint(e.out, "^C\n")
+			return "", errInterrupt
+		case 4: // ^D
+			if len(e.buf) == 0 {
+				fmt.Fprint(e.out, "\n")
+				return "", io.EOF
+			}
+			e.delete(e.pos, e.pos+1)
+		case 127, 8: // backspace, ^H
+			e.delete(e.pos-1, e.pos)
+		case 1: // ^A
+			e.pos = 0
+		case 5: // ^E
+			e.pos = len(e.buf)
+		case 2: // ^B
+			e.move(-1)
+		case 6: // ^F
+			e.move(1)
+		case 11: // ^K
+			e.buf = e.buf[:e.pos]
+		case 21: // ^U
+			e.delete(0, e.pos)
+		case 23: // ^W
+			start := e.pos
+			for start > 0 && unicode.IsSpace(e.buf[start-1]) {
+				start--
+			}
+			for start > 0 && !unicode.IsSpace(e.buf[start-1]) {
+				start--
+			}
+			e.delete(start, e.pos)
+		case 12: // ^L
+			fmt.Fprint(e.out, "\x1b[H\x1b[2J")
+		case 16: // ^P
+			hist = e.recall(hist - 1)
+		case 14: // ^N
+			hist = e.recall(hist + 1)
+		case '\t':
+			e.completeWord()
+		case 27: // escape sequence
+			hist = e.escape(hist)
+		default:
+			if unicode.IsPrint(r) {
+				e.insert(string(r))
+			}
+		}
+		e.refresh()
+	}
+}
+
+// escape handles the escape sequences of arrow, home, end and delete keys.
+func (e *lineEditor) escape(hist int) int {
+	b, err := e.in.ReadByte()
+	if err != nil || b != '[' && b != 'O' {
+		return hist
+	}
+	seq, err := e.in.ReadByte()
+	if err != nil {
+		return hist
+	}
+	if '0' <= seq && seq <= '9' {
+		// Extended sequences end with ~, as in ESC [ 3 ~.
+		if end, err := e.in.ReadByte(); err != nil || end != '~' {
+			return hist
+		}
+	}
+
+	switch seq {
+	case 'A':
+		return e.recall(hist - 1)
+	case 'B':
+		return e.recall(hist + 1)
+	case 'C':
+		e.move(1)
+	case 'D':
+		e.move(-1)
+	case 'H', '1', '7':
+		e.pos = 0
+	case 'F', '4', '8':
+		e.pos = len(e.buf)
+	case '3':
+		e.delete(e.pos, e.pos+1)
+	}
+	return hist
+}
+
+// recall replaces the line with the history entry at i, past the last entry
+// being an empty line, and returns the index of the entry shown.
+func (e *lineEditor) recall(i int) int {
+	switch {
+	case i < 0:
+		return 0
+	case i >= len(e.history):
+		e.buf = nil
+		i = len(e.history)
+	default:
+		e.buf = []rune(e.history[i])
+	}
+	e.pos = len(e.buf)
+	return i
+}
+
+func (e *lineEditor) move(n int) {
+	if p := e.pos + n; p >= 0 && p <= len(e.buf) {
+		e.pos = p
+	}
+}
+
+func (e *lineEditor) insert(s string) {
+	r := []rune(s)
+	e.buf = append(e.buf[:e.pos], append(r, e.buf[e.pos:]...)...)
+	e.pos += len(r)
+}
+
+// delete removes the runes from i to j, if they are within the line.
+func (e *lineEditor) delete(i, j int) {
+	if i < 0 || j > len(e.buf) || i >= j {
+		return
+	}
+	e.buf = append(e.buf[:i], e.buf[j:]...)
+	if e.pos > i {
+		e.pos -= j - i
+		if e.pos < i {
+			e.pos = i
+		}
+	}
+}
+
+// completeWord completes the word before the cursor. A single completion
+// replaces it, several are completed to their common prefix or else listed
+// below the line.
+func (e *lineEditor) completeWord() {
+	if e.complete == nil {
+		return
+	}
+	start := e.pos
+	for start > 0 && !unicode.IsSpace(e.buf[start-1]) {
+		start--
+	}
+	word := string(e.buf[start:e.pos])
+
+	completions, space := e.complete(string(e.buf[:e.pos]))
+	switch len(completions) {
+	case 0:
+		return
+	case 1:
+		e.delete(start, e.pos)
+		e.insert(completions[0])
+		if space {
+			e.insert(" ")
+		}
+		return
+	}
+
+	if prefix := commonPrefix(completions); len(prefix) > len(word) {
+		e.delete(start, e.pos)
+		e.insert(prefix)
+		return
+	}
+	sort.Strings(completions)
+	fmt.Fprintf(e.out, "\n%s\n", strings.Join(completions, "  "))
+}
+
+// refresh redraws the line and places the cursor.
+func (e *lineEditor) refresh() {
+	fmt.Fprintf(e.out, "\r%s%s\x1b[K", e.prompt, string(e.buf))
+	if n := len(e.buf) - e.pos; n > 0 {
+		fmt.Fprintf(e.out, "\x1b[%dD", n)
+	}
+}
+
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, w := range words[1:] {
+		for !strings.HasPrefix(w, prefix) {
+			_, n := utf8.DecodeLastRuneInString(prefix)
+			prefix = prefix[:len(prefix)-n]
+		}
+	}
+	return prefix
+}
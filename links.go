@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+)
+
+const installLinksLong = `Install-links creates a symbolic link to the gosh binary in dir for every
+command group. Invoked through a link, gosh runs the group it is named after,
+so that a single binary provides gostrings, gofilepath, gonet and gourl:
+
+  $ gosh install-links /usr/local/bin
+  /usr/local/bin/gostrings
+  ...
+  $ gostrings toupper abc
+  ABC`
+
+func newInstallLinksCommand(groups []*cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install-links dir",
+		Short: "Link the command groups to the gosh binary",
+		Long:  installLinksLong,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			force, err := cmd.Flags().GetBool("force")
+			if err != nil {
+				return err
+			}
+			exe, err := os.Executable()
+			if err != nil {
+				return err
+			}
+			if exe, err = filepath.EvalSymlinks(exe); err != nil {
+				return err
+			}
+
+			for _, g := range groups {
+				link := filepath.Join(args[0], g.Name())
+				if force {
+					if err := os.Remove(link); err != nil && !os.IsNotExist(err) {
+						return err
+					}
+				}
+				if err := os.Symlink(exe, link); err != nil {
+					return err
+				}
+				fmt.Fprintln(cmd.OutOrStdout(), link)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolP("force", "f", false, "replace existing files")
+	return cmd
+}
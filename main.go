@@ -2,6 +2,8 @@ package main
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/aca/gosh/cmds/gofilepath"
 	"github.com/aca/gosh/cmds/gonet"
@@ -11,19 +13,70 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// groups are the command groups, which gosh also runs as their own binary
+// when invoked through a link named after one of them.
+var groups = []*cobra.Command{gostrings.Cmd, gofilepath.Cmd, gonet.Cmd, gourl.Cmd}
+
+// pipeGroups are the groups functions of pipelines are looked up in, in order.
+var pipeGroups = []string{"gostrings", "gofilepath", "gourl", "gonet"}
+
 func main() {
+	// Aliases are commands of the groups, added from the config.
+	aliases := func(c *utils.Config) error {
+		return addAliases(c, groups)
+	}
+
+	name := strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe")
+	for _, g := range groups {
+		if g.Name() == name {
+			utils.UseConfig(g, aliases)
+			os.Exit(utils.Execute(g))
+		}
+	}
+
 	cmdRoot := &cobra.Command{
 		Use:          "gosh",
 		SilenceUsage: true,
 	}
-	cmdRoot.AddCommand(gostrings.Cmd)
-	cmdRoot.AddCommand(gofilepath.Cmd)
-	cmdRoot.AddCommand(gonet.Cmd)
-	cmdRoot.AddCommand(gourl.Cmd)
+	for _, g := range groups {
+		cmdRoot.AddCommand(g)
+	}
+
+	pipe := newPipeCommand(pipeGroups...)
+	cmdRoot.AddCommand(pipe)
+	utils.SetExamples(pipe, utils.Example{
+		Args:   []string{"x/y", `split "/" | gofilepath.join /root`},
+		Output: "/root/x/y",
+	}, utils.Example{
+		Args:   []string{`trimspace | split "," | toupper | join "-"`},
+		Stdin:  " a,b \n",
+		Output: "A-B",
+	}, utils.Example{
+		Args: []string{"a/b", `split "/" | base`},
+		Exit: utils.ExitUsage,
+	})
+
+	serve := newServeCommand(cmdRoot)
+	cmdRoot.AddCommand(serve)
+	utils.SetExamples(serve, utils.Example{
+		Args:   []string{"--stdio"},
+		Stdin:  `{"id":1,"cmd":"gofilepath.rel","args":["a","a/b"]}` + "\n",
+		Output: `{"id":1,"result":"b","exit":0}` + "\n",
+	})
 
+	repl := newReplCommand(cmdRoot)
+	cmdRoot.AddCommand(repl)
+	utils.SetExamples(repl, utils.Example{
+		Stdin:  "gostrings toupper abc\ngostrings repeat $_ 2\n",
+		Output: "ABC\nABCABC\n",
+	})
+
+	cmdRoot.AddCommand(newInstallLinksCommand(groups))
+	cmdRoot.AddCommand(newSelftestCommand(cmdRoot))
 	cmdRoot.AddCommand(utils.NewCompletionCommand("gosh"))
+	addPlugins(cmdRoot)
+	utils.AddErrorFlags(cmdRoot)
+	utils.UseConfig(cmdRoot, aliases)
 
-	if err := cmdRoot.Execute(); err != nil {
-		os.Exit(1)
-	}
+	os.Exit(utils.Execute(cmdRoot))
 }
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"github.com/aca/gosh/utils"
+	"github.com/spf13/cobra"
+)
+
+const pipeLong = `Pipe runs a chain of functions in one process, passing the results of each
+stage to the next one, instead of a shell pipeline of gosh commands.
+
+Each stage names a function followed by its arguments, except the first one
+which is the value output by the previous stage. The input of the first stage
+is s, or stdin if s is not given.
+
+  $ echo ' a,b ' | gosh pipe 'trimspace | split "," | toupper'
+  A
+  B
+
+Slice and multiple results are passed on element by element, and functions
+returning a bool keep the values they are true for. Variadic functions, such as
+gostrings join or gofilepath join, are called once with all the values, but
+for gostrings replacer which is called for each one.
+
+The function of the first stage is looked up in gostrings, gofilepath, gourl
+and gonet, in this order, and the next ones in the group of the previous
+stage; prefix a name with its group, as in gofilepath.split or gofilepath
+split, to pick another one. Arguments are bare words, Go string literals in
+double quotes or back quotes, or raw strings in single quotes.`
+
+func newPipeCommand(groups ...string) *cobra.Command {
+	var (
+		expr string
+		pl   *utils.Pipeline
+	)
+
+	cmd := &cobra.Command{
+		Use:                   "pipe [s] expr",
+		Short:                 "Run a pipeline of functions in-process",
+		Long:                  pipeLong,
+		Args:                  cobra.RangeArgs(1, 2),
+		DisableFlagsInUseLine: true,
+		RunE: utils.Run(2, func(cmd *cobra.Command, args []string, p *utils.Printer) error {
+			// In line mode the same expression is run for every line.
+			if pl == nil || args[1] != expr {
+				var err error
+				if pl, err = utils.ParsePipeline(args[1], groups...); err != nil {
+					return err
+				}
+				expr = args[1]
+			}
+
+			return runPipeline(pl, args[0], p)
+		}),
+	}
+	utils.AddPersistentFlags(cmd)
+	return cmd
+}
+
+// runPipeline runs pl on s and prints its output, a single value as a scalar.
+func runPipeline(pl *utils.Pipeline, s string, p *utils.Printer) error {
+	values, err := pl.Run([]string{s})
+	if err != nil {
+		return err
+	}
+	if len(values) == 1 {
+		return p.Print(values[0])
+	}
+	return p.Print(values)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/aca/gosh/utils"
+	"github.com/spf13/cobra"
+)
+
+// pluginPrefix is the prefix of the executables run as gosh commands.
+const pluginPrefix = "gosh-"
+
+// completeEnv is the environment variable listing the plugins, by name and
+// separated by spaces or commas, that implement the __complete protocol.
+const completeEnv = "GOSH_COMPLETE_PLUGINS"
+
+// addPlugins adds a command to root for every executable named gosh-<name>
+// on PATH, unless root already has a command name. The first one found in
+// PATH order wins, as for the shell.
+func addPlugins(root *cobra.Command) {
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			dir = "."
+		}
+		infos, err := ioutil.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, fi := range infos {
+			name, ok := pluginName(fi)
+			if !ok {
+				continue
+			}
+			if c, _, err := root.Find([]string{name}); err == nil && c != root {
+				continue
+			}
+			root.AddCommand(newPluginCommand(name, filepath.Join(dir, fi.Name())))
+		}
+	}
+}
+
+// pluginName returns the name of the command run by the plugin fi, and
+// whether fi is a plugin.
+func pluginName(fi os.FileInfo) (string, bool) {
+	name := fi.Name()
+	if !strings.HasPrefix(name, pluginPrefix) || fi.IsDir() {
+		return "", false
+	}
+	if runtime.GOOS == "windows" {
+		if !strings.EqualFold(filepath.Ext(name), ".exe") {
+			return "", false
+		}
+		name = name[:len(name)-len(".exe")]
+	} else if fi.Mode()&0111 == 0 {
+		return "", false
+	}
+
+	name = strings.TrimPrefix(name, pluginPrefix)
+	return name, name != ""
+}
+
+// newPluginCommand returns the command running the plugin at path. Arguments,
+// flags included, are passed on as is, along with stdin, stdout and stderr.
+//
+// Plugins may implement the __complete protocol of cobra to provide
+// completions: called with __complete, the arguments and the word to complete,
+// they print the completions one per line, followed by :directive. As other
+// plugins would run it as an argument, they are only called so once listed in
+// $GOSH_COMPLETE_PLUGINS.
+func newPluginCommand(name, path string) *cobra.Command {
+	return &cobra.Command{
+		Use:                name,
+		Short:              "Plugin " + path,
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c := exec.Command(path, args...)
+			c.Stdin = cmd.InOrStdin()
+			c.Stdout = cmd.OutOrStdout()
+			c.Stderr = cmd.ErrOrStderr()
+			return c.Run()
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if !completes(name) {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			args = append(append([]string{cobra.ShellCompRequestCmd}, args...), toComplete)
+			out, err := exec.Command(path, args...).Output()
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveDefault
+			}
+			completions, directive, err := utils.ParseCompletions(string(out))
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveDefault
+			}
+			return completions, directive
+		},
+	}
+}
+
+// completes reports whether the plugin name is listed in $GOSH_COMPLETE_PLUGINS.
+func completes(name string) bool {
+	list := strings.FieldsFunc(os.Getenv(completeEnv), func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	for _, n := range list {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
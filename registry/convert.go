@@ -0,0 +1,158 @@
+package registry
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ArgError is returned when an argument can't be converted to the type of
+// the parameter it is passed for.
+type ArgError struct {
+	Type string
+	Arg  string
+}
+
+func (e *ArgError) Error() string {
+	return "invalid " + e.Type + " argument: " + strconv.Quote(e.Arg)
+}
+
+// parseInt converts an argument to an int parameter.
+func parseInt(s string) (int, error) {
+	return strconv.Atoi(s)
+}
+
+// parseRune converts an argument holding a single character to a rune
+// parameter.
+func parseRune(s string) (rune, error) {
+	r, n := utf8.DecodeRuneInString(s)
+	if n == 0 || n != len(s) {
+		return 0, &ArgError{Type: "rune", Arg: s}
+	}
+	return r, nil
+}
+
+// parseByte converts an argument holding a single byte to a byte parameter.
+func parseByte(s string) (byte, error) {
+	if len(s) != 1 {
+		return 0, &ArgError{Type: "byte", Arg: s}
+	}
+	return s[0], nil
+}
+
+// parseBool converts an argument to a bool parameter.
+func parseBool(s string) (bool, error) {
+	return strconv.ParseBool(s)
+}
+
+// predicates are the rune predicates func(rune) bool parameters are given as,
+// by name.
+var predicates = map[string]func(rune) bool{
+	"IsControl": unicode.IsControl,
+	"IsDigit":   unicode.IsDigit,
+	"IsGraphic": unicode.IsGraphic,
+	"IsLetter":  unicode.IsLetter,
+	"IsLower":   unicode.IsLower,
+	"IsMark":    unicode.IsMark,
+	"IsNumber":  unicode.IsNumber,
+	"IsPrint":   unicode.IsPrint,
+	"IsPunct":   unicode.IsPunct,
+	"IsSpace":   unicode.IsSpace,
+	"IsSymbol":  unicode.IsSymbol,
+	"IsTitle":   unicode.IsTitle,
+	"IsUpper":   unicode.IsUpper,
+}
+
+// rangeTables are the range tables of package unicode rune predicates may be
+// given as, by name, in order of precedence.
+var rangeTables = []map[string]*unicode.RangeTable{
+	unicode.Categories,
+	unicode.Scripts,
+	unicode.Properties,
+}
+
+// mappings are the rune mappings func(rune) rune parameters are given as, by
+// name.
+var mappings = map[string]func(rune) rune{
+	"ToLower": unicode.ToLower,
+	"ToTitle": unicode.ToTitle,
+	"ToUpper": unicode.ToUpper,
+}
+
+// ParsePredicate converts a rune predicate argument to a func(rune) bool
+// parameter. It is either the name of a function of package unicode, such as
+// IsDigit, or the name of one of its range tables: a category such as Lu, a
+// script such as Han or a property such as White_Space. A leading ! negates
+// the predicate. Names are case-insensitive.
+func ParsePredicate(s string) (func(rune) bool, error) {
+	name := strings.TrimPrefix(s, "!")
+	f := lookupPredicate(name)
+	if f == nil {
+		return nil, &ArgError{Type: "rune predicate", Arg: s}
+	}
+	if name != s {
+		return func(r rune) bool { return !f(r) }, nil
+	}
+	return f, nil
+}
+
+// lookupPredicate returns the predicate named name, or nil.
+func lookupPredicate(name string) func(rune) bool {
+	for n, f := range predicates {
+		if strings.EqualFold(name, n) {
+			return f
+		}
+	}
+	for _, tables := range rangeTables {
+		for n, t := range tables {
+			if strings.EqualFold(name, n) {
+				t := t
+				return func(r rune) bool { return unicode.Is(t, r) }
+			}
+		}
+	}
+	return nil
+}
+
+// parseMapping converts the name of a function of package unicode, such as
+// ToUpper, to a func(rune) rune parameter. Names are case-insensitive.
+func parseMapping(s string) (func(rune) rune, error) {
+	for name, f := range mappings {
+		if strings.EqualFold(s, name) {
+			return f, nil
+		}
+	}
+	return nil, &ArgError{Type: "rune mapping", Arg: s}
+}
+
+// PredicateNames returns the names rune predicates are given as, sorted: the
+// functions of package unicode, then its range tables.
+func PredicateNames() []string {
+	names := make([]string, 0, len(predicates))
+	for name := range predicates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var tables []string
+	for _, t := range rangeTables {
+		for name := range t {
+			tables = append(tables, name)
+		}
+	}
+	sort.Strings(tables)
+	return append(names, tables...)
+}
+
+// MappingNames returns the names func(rune) rune parameters are given as,
+// sorted.
+func MappingNames() []string {
+	names := make([]string, 0, len(mappings))
+	for name := range mappings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,167 @@
+package registry
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseRune(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want rune
+		err  bool
+	}{
+		{arg: "a", want: 'a'},
+		{arg: "é", want: 'é'},
+		{arg: "世", want: '世'},
+		{arg: "", err: true},
+		{arg: "ab", err: true},
+	}
+	for _, tt := range tests {
+		got, err := parseRune(tt.arg)
+		if tt.err {
+			var argErr *ArgError
+			if !errors.As(err, &argErr) {
+				t.Errorf("parseRune(%q): got error %v, want an ArgError", tt.arg, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseRune(%q) = %q, %v, want %q", tt.arg, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseByte(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want byte
+		err  bool
+	}{
+		{arg: "a", want: 'a'},
+		{arg: "\xff", want: 0xff},
+		{arg: "", err: true},
+		{arg: "é", err: true},
+	}
+	for _, tt := range tests {
+		got, err := parseByte(tt.arg)
+		if tt.err {
+			var argErr *ArgError
+			if !errors.As(err, &argErr) {
+				t.Errorf("parseByte(%q): got error %v, want an ArgError", tt.arg, err)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("parseByte(%q) = %q, %v, want %q", tt.arg, got, err, tt.want)
+		}
+	}
+}
+
+func TestParsePredicate(t *testing.T) {
+	tests := []struct {
+		arg   string
+		match string // runes the predicate holds for
+		other string // runes it doesn't
+	}{
+		{"IsDigit", "09٣", "a "},
+		{"isdigit", "0", "a"},
+		{"!IsDigit", "a ", "09"},
+		{"IsSpace", " \t\n", "a"},
+		{"Lu", "AÉ", "aé1"},
+		{"lu", "A", "a"},
+		{"Han", "世界", "aア"},
+		{"!han", "aア", "世"},
+		{"White_Space", "  ", "a"},
+	}
+	for _, tt := range tests {
+		f, err := ParsePredicate(tt.arg)
+		if err != nil {
+			t.Errorf("ParsePredicate(%q): %v", tt.arg, err)
+			continue
+		}
+		for _, r := range tt.match {
+			if !f(r) {
+				t.Errorf("ParsePredicate(%q)(%q) = false", tt.arg, r)
+			}
+		}
+		for _, r := range tt.other {
+			if f(r) {
+				t.Errorf("ParsePredicate(%q)(%q) = true", tt.arg, r)
+			}
+		}
+	}
+
+	for _, arg := range []string{"", "!", "IsNothing", "!!IsDigit"} {
+		_, err := ParsePredicate(arg)
+		if want := (&ArgError{Type: "rune predicate", Arg: arg}); !reflect.DeepEqual(err, want) {
+			t.Errorf("ParsePredicate(%q): got error %v, want %v", arg, err, want)
+		}
+	}
+}
+
+func TestParseMapping(t *testing.T) {
+	tests := []struct {
+		arg     string
+		in, out rune
+	}{
+		{"ToUpper", 'é', 'É'},
+		{"toupper", 'a', 'A'},
+		{"ToLower", 'A', 'a'},
+		{"ToTitle", 'ǆ', 'ǅ'},
+	}
+	for _, tt := range tests {
+		f, err := parseMapping(tt.arg)
+		if err != nil {
+			t.Errorf("parseMapping(%q): %v", tt.arg, err)
+			continue
+		}
+		if got := f(tt.in); got != tt.out {
+			t.Errorf("parseMapping(%q)(%q) = %q, want %q", tt.arg, tt.in, got, tt.out)
+		}
+	}
+
+	for _, arg := range []string{"", "IsUpper", "Upper"} {
+		_, err := parseMapping(arg)
+		if want := (&ArgError{Type: "rune mapping", Arg: arg}); !reflect.DeepEqual(err, want) {
+			t.Errorf("parseMapping(%q): got error %v, want %v", arg, err, want)
+		}
+	}
+}
+
+func TestInvoke(t *testing.T) {
+	tests := []struct {
+		group, name string
+		args        []string
+		want        []interface{}
+		err         string
+	}{
+		{"gostrings", "indexrune", []string{"chicken", "k"}, []interface{}{4}, ""},
+		{"gostrings", "indexrune", []string{"chicken", "kk"}, nil, `invalid rune argument: "kk"`},
+		{"gostrings", "repeat", []string{"ab", "2"}, []interface{}{"abab"}, ""},
+		{"gostrings", "repeat", []string{"ab"}, nil, "repeat takes 2 argument(s), got 1"},
+		{"gostrings", "indexfunc", []string{"ab1", "IsDigit"}, []interface{}{2}, ""},
+		{"gostrings", "map", []string{"ToUpper", "ab"}, []interface{}{"AB"}, ""},
+		{"gofilepath", "join", nil, []interface{}{""}, ""},
+		{"gofilepath", "join", []string{"a", "b"}, []interface{}{"a/b"}, ""},
+		{"gofilepath", "glob", []string{"["}, nil, "syntax error in pattern"},
+		{"gourl", "pathunescape", []string{"%zz"}, nil, `invalid URL escape "%zz"`},
+	}
+	for _, tt := range tests {
+		f := Lookup(tt.group, tt.name)
+		if f == nil {
+			t.Fatalf("no function %s in %s", tt.name, tt.group)
+		}
+		got, err := f.Invoke(tt.args)
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("%s %q: got error %v, want %s", tt.name, tt.args, err, tt.err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s %q = %#v, %v, want %#v", tt.name, tt.args, got, err, tt.want)
+		}
+	}
+}
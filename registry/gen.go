@@ -0,0 +1,346 @@
+//go:build ignore
+// +build ignore
+
+// gen generates the Funcs of a command group from the Go package it wraps.
+//
+// It is run by go generate in the registry package, and reads the names of
+// the functions to wrap from the list of the group, such as gostrings.txt, one
+// per line. Signatures and docs come from the package sources, so the
+// commands always match the functions they call.
+//
+//	//go:generate go run gen.go -pkg strings -group gostrings
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/doc"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// converters maps parameter types to the function converting an argument to
+// them. String parameters are passed as is.
+var converters = map[string]string{
+	"int":   "parseInt",
+	"rune":  "parseRune",
+	"int32": "parseRune",
+	"byte":  "parseByte",
+	"uint8": "parseByte",
+	"bool":  "parseBool",
+
+	// Functions of package unicode, by name.
+	"func(rune) bool": "ParsePredicate",
+	"func(rune) rune": "parseMapping",
+}
+
+// helpers maps the functions newer than the go version of go.mod, by package
+// path and name, to the copies of them in the registry package that are
+// called in their place, so that gosh still builds with that version.
+var helpers = map[string]string{
+	"strings.Clone":        "stringsClone",
+	"strings.ContainsFunc": "stringsContainsFunc",
+	"strings.Cut":          "stringsCut",
+	"strings.CutLast":      "stringsCutLast",
+	"strings.CutPrefix":    "stringsCutPrefix",
+	"strings.CutSuffix":    "stringsCutSuffix",
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("gen: ")
+
+	pkgPath := flag.String("pkg", "", "import path of the wrapped package")
+	group := flag.String("group", "", "command group of the functions")
+	list := flag.String("list", "", "file listing the functions to wrap (default group.txt)")
+	out := flag.String("o", "", "output file (default group_generated.go)")
+	flag.Parse()
+
+	if *pkgPath == "" || *group == "" {
+		log.Fatal("missing -pkg or -group")
+	}
+	if *list == "" {
+		*list = *group + ".txt"
+	}
+	if *out == "" {
+		*out = *group + "_generated.go"
+	}
+	pkgName := os.Getenv("GOPACKAGE")
+	if pkgName == "" {
+		log.Fatal("GOPACKAGE is not set, gen must be run by go generate")
+	}
+
+	names, err := readList(*list)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Cgo sources can't be type-checked from source; the pure Go
+	// implementations declare the same API.
+	build.Default.CgoEnabled = false
+
+	fset := token.NewFileSet()
+	pkg, err := importer.ForCompiler(fset, "source", nil).Import(*pkgPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	docs, err := funcDocs(fset, *pkgPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	g := &generator{fset: fset, pkg: pkg, docs: docs}
+	src, err := g.generate(pkgName, *group, *list, names)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readList reads the function names listed in path, ignoring blank lines and
+// # comments.
+func readList(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var names []string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			names = append(names, line)
+		}
+	}
+	return names, sc.Err()
+}
+
+// funcDocs returns the documentation of the package level functions of the
+// package at path, by name.
+func funcDocs(fset *token.FileSet, path string) (map[string]*doc.Func, error) {
+	bp, err := build.Import(path, "", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, name := range bp.GoFiles {
+		f, err := parser.ParseFile(fset, filepath.Join(bp.Dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+
+	p, err := doc.NewFromFiles(fset, files, path)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(map[string]*doc.Func)
+	for _, f := range p.Funcs {
+		docs[f.Name] = f
+	}
+	// Functions returning a type, like constructors, are listed with it.
+	for _, t := range p.Types {
+		for _, f := range t.Funcs {
+			docs[f.Name] = f
+		}
+	}
+	return docs, nil
+}
+
+type generator struct {
+	fset *token.FileSet
+	pkg  *types.Package
+	docs map[string]*doc.Func
+
+	buf bytes.Buffer
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) generate(pkgName, group, list string, names []string) ([]byte, error) {
+	g.printf("// Code generated by gen.go from %s; DO NOT EDIT.\n\n", list)
+	g.printf("package %s\n\n", pkgName)
+	g.printf("import %q\n\n", g.pkg.Path())
+	g.printf("// The functions of package %s wrapped by %s.\n", g.pkg.Name(), group)
+	g.printf("func init() {\n")
+	g.printf("register(%q, []*Func{\n", group)
+
+	seen := make(map[string]string)
+	for _, name := range names {
+		cmdName := strings.ToLower(name)
+		if prev, ok := seen[cmdName]; ok {
+			return nil, fmt.Errorf("%s and %s both map to command %q", prev, name, cmdName)
+		}
+		seen[cmdName] = name
+
+		if err := g.generateFunc(cmdName, name); err != nil {
+			return nil, err
+		}
+	}
+	g.printf("})\n")
+	g.printf("}\n")
+
+	src, err := format.Source(g.buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %v\n%s", err, g.buf.Bytes())
+	}
+	return src, nil
+}
+
+func (g *generator) generateFunc(cmdName, name string) error {
+	obj, ok := g.pkg.Scope().Lookup(name).(*types.Func)
+	if !ok || !obj.Exported() {
+		return fmt.Errorf("%s.%s is not an exported function", g.pkg.Path(), name)
+	}
+	d, ok := g.docs[name]
+	if !ok {
+		return fmt.Errorf("no documentation found for %s.%s", g.pkg.Path(), name)
+	}
+
+	sig := obj.Type().(*types.Signature)
+	qual := types.RelativeTo(g.pkg)
+
+	var decl bytes.Buffer
+	printer.Fprint(&decl, g.fset, &ast.FuncDecl{Name: d.Decl.Name, Type: d.Decl.Type})
+
+	g.printf("{\n")
+	g.printf("Name: %q,\n", cmdName)
+	g.printf("Package: %q,\n", g.pkg.Path())
+	g.printf("Signature: %q,\n", decl.String())
+	g.printf("Doc: %s,\n", goString(strings.TrimSpace(d.Doc)))
+
+	// Parameters and the arguments passed for them.
+	var callArgs []string
+	var convs bytes.Buffer
+	g.printf("Params: []Param{\n")
+	for i := 0; i < sig.Params().Len(); i++ {
+		v := sig.Params().At(i)
+		typ := types.TypeString(v.Type(), qual)
+		variadic := sig.Variadic() && i == sig.Params().Len()-1
+		if variadic {
+			typ = "..." + strings.TrimPrefix(typ, "[]")
+		}
+		g.printf("{Name: %q, Type: %q},\n", paramName(v.Name(), i), typ)
+
+		switch {
+		case typ == "string":
+			callArgs = append(callArgs, fmt.Sprintf("args[%d]", i))
+		case typ == "...string":
+			callArgs = append(callArgs, fmt.Sprintf("args[%d:]...", i))
+		case converters[typ] != "":
+			fmt.Fprintf(&convs, "a%d, err := %s(args[%d])\nif err != nil {\nreturn nil, err\n}\n", i, converters[typ], i)
+			callArgs = append(callArgs, fmt.Sprintf("a%d", i))
+		default:
+			return fmt.Errorf("%s: unsupported parameter type %s", name, typ)
+		}
+	}
+	g.printf("},\n")
+
+	// Results, without a trailing error.
+	var vals []string
+	withErr := false
+	used := make(map[string]bool)
+	g.printf("Results: []Param{\n")
+	for i := 0; i < sig.Results().Len(); i++ {
+		v := sig.Results().At(i)
+		typ := types.TypeString(v.Type(), qual)
+		if i == sig.Results().Len()-1 && typ == "error" {
+			withErr = true
+			break
+		}
+		rname := resultName(v.Name(), typ, i)
+		if used[rname] {
+			rname += strconv.Itoa(i)
+		}
+		used[rname] = true
+		g.printf("{Name: %q, Type: %q},\n", rname, typ)
+		vals = append(vals, fmt.Sprintf("r%d", i))
+	}
+	g.printf("},\n")
+
+	if sig.Variadic() {
+		g.printf("Variadic: true,\n")
+	}
+
+	fn := g.pkg.Name() + "." + name
+	if h, ok := helpers[g.pkg.Path()+"."+name]; ok {
+		fn = h
+	}
+	call := fmt.Sprintf("%s(%s)", fn, strings.Join(callArgs, ", "))
+	g.printf("fn: func(args []string) ([]interface{}, error) {\n")
+	g.buf.Write(convs.Bytes())
+	switch {
+	case len(vals) == 0 && withErr:
+		g.printf("return nil, %s\n", call)
+	case len(vals) == 0:
+		g.printf("%s\nreturn nil, nil\n", call)
+	case withErr:
+		g.printf("%s, err := %s\nif err != nil {\nreturn nil, err\n}\n", strings.Join(vals, ", "), call)
+		g.printf("return []interface{}{%s}, nil\n", strings.Join(vals, ", "))
+	case len(vals) == 1:
+		g.printf("return []interface{}{%s}, nil\n", call)
+	default:
+		g.printf("%s := %s\n", strings.Join(vals, ", "), call)
+		g.printf("return []interface{}{%s}, nil\n", strings.Join(vals, ", "))
+	}
+	g.printf("},\n")
+	g.printf("},\n")
+	return nil
+}
+
+func paramName(name string, i int) string {
+	if name == "" || name == "_" {
+		return fmt.Sprintf("arg%d", i)
+	}
+	return name
+}
+
+// resultName names a result after its type if it is unnamed, so that the
+// results of ParseCIDR, (IP, *IPNet, error), are printed as ip and ipnet.
+func resultName(name, typ string, i int) string {
+	if name != "" && name != "_" {
+		return name
+	}
+	typ = strings.TrimLeft(typ, "*[]")
+	if i := strings.LastIndexByte(typ, '.'); i >= 0 {
+		typ = typ[i+1:]
+	}
+	if typ == "" {
+		return fmt.Sprintf("r%d", i)
+	}
+	return strings.ToLower(typ)
+}
+
+// goString returns s as a Go string literal, raw if possible.
+func goString(s string) string {
+	if strings.Contains(s, "`") || strings.Contains(s, "\r") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
@@ -0,0 +1,317 @@
+// Code generated by gen.go from gofilepath.txt; DO NOT EDIT.
+
+package registry
+
+import "path/filepath"
+
+// The functions of package filepath wrapped by gofilepath.
+func init() {
+	register("gofilepath", []*Func{
+		{
+			Name:      "abs",
+			Package:   "path/filepath",
+			Signature: "func Abs(path string) (string, error)",
+			Doc: `Abs returns an absolute representation of path.
+If the path is not absolute it will be joined with the current
+working directory to turn it into an absolute path. The absolute
+path name for a given file is not guaranteed to be unique.
+Abs calls [Clean] on the result.`,
+			Params: []Param{
+				{Name: "path", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				r0, err := filepath.Abs(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{r0}, nil
+			},
+		},
+		{
+			Name:      "base",
+			Package:   "path/filepath",
+			Signature: "func Base(path string) string",
+			Doc: `Base returns the last element of path.
+Trailing path separators are removed before extracting the last element.
+If the path is empty, Base returns ".".
+If the path consists entirely of separators, Base returns a single separator.`,
+			Params: []Param{
+				{Name: "path", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{filepath.Base(args[0])}, nil
+			},
+		},
+		{
+			Name:      "clean",
+			Package:   "path/filepath",
+			Signature: "func Clean(path string) string",
+			Doc:       "Clean returns the shortest path name equivalent to path\nby purely lexical processing. It applies the following rules\niteratively until no further processing can be done:\n\n 1. Replace multiple [Separator] elements with a single one.\n 2. Eliminate each . path name element (the current directory).\n 3. Eliminate each inner .. path name element (the parent directory)\n    along with the non-.. element that precedes it.\n 4. Eliminate .. elements that begin a rooted path:\n    that is, replace \"/..\" by \"/\" at the beginning of a path,\n    assuming Separator is '/'.\n\nThe returned path ends in a slash only if it represents a root directory,\nsuch as \"/\" on Unix or `C:\\` on Windows.\n\nFinally, any occurrences of slash are replaced by Separator.\n\nIf the result of this process is an empty string, Clean\nreturns the string \".\".\n\nOn Windows, Clean does not modify the volume name other than to replace\noccurrences of \"/\" with `\\`.\nFor example, Clean(\"//host/share/../x\") returns `\\\\host\\share\\x`.\n\nSee also Rob Pike, “Lexical File Names in Plan 9 or\nGetting Dot-Dot Right,”\nhttps://9p.io/sys/doc/lexnames.html",
+			Params: []Param{
+				{Name: "path", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{filepath.Clean(args[0])}, nil
+			},
+		},
+		{
+			Name:      "dir",
+			Package:   "path/filepath",
+			Signature: "func Dir(path string) string",
+			Doc: `Dir returns all but the last element of path, typically the path's directory.
+After dropping the final element, Dir calls [Clean] on the path and trailing
+slashes are removed.
+If the path is empty, Dir returns ".".
+If the path consists entirely of separators, Dir returns a single separator.
+The returned path does not end in a separator unless it is the root directory.
+
+On Windows, given a volume-only name such as "C:", Dir returns "C:.",
+the current directory on drive C. To obtain the drive's root "C:\",
+use [VolumeName] combined with a separator.`,
+			Params: []Param{
+				{Name: "path", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{filepath.Dir(args[0])}, nil
+			},
+		},
+		{
+			Name:      "evalsymlinks",
+			Package:   "path/filepath",
+			Signature: "func EvalSymlinks(path string) (string, error)",
+			Doc: `EvalSymlinks returns the path name after the evaluation of any symbolic
+links.
+If path is relative the result will be relative to the current directory,
+unless one of the components is an absolute symbolic link.
+EvalSymlinks calls [Clean] on the result.`,
+			Params: []Param{
+				{Name: "path", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				r0, err := filepath.EvalSymlinks(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{r0}, nil
+			},
+		},
+		{
+			Name:      "ext",
+			Package:   "path/filepath",
+			Signature: "func Ext(path string) string",
+			Doc: `Ext returns the file name extension used by path.
+The extension is the suffix beginning at the final dot
+in the final element of path; it is empty if there is
+no dot.`,
+			Params: []Param{
+				{Name: "path", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{filepath.Ext(args[0])}, nil
+			},
+		},
+		{
+			Name:      "fromslash",
+			Package:   "path/filepath",
+			Signature: "func FromSlash(path string) string",
+			Doc: `FromSlash returns the result of replacing each slash ('/') character
+in path with a separator character. Multiple slashes are replaced
+by multiple separators.
+
+See also the Localize function, which converts a slash-separated path
+as used by the io/fs package to an operating system path.`,
+			Params: []Param{
+				{Name: "path", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{filepath.FromSlash(args[0])}, nil
+			},
+		},
+		{
+			Name:      "glob",
+			Package:   "path/filepath",
+			Signature: "func Glob(pattern string) (matches []string, err error)",
+			Doc: `Glob returns the names of all files matching pattern or nil
+if there is no matching file. The syntax of patterns is the same
+as in [Match]. The pattern may describe hierarchical names such as
+/usr/*/bin/ed (assuming the [Separator] is '/').
+
+Glob ignores file system errors such as I/O errors reading directories.
+The only possible returned error is [ErrBadPattern], when pattern
+is malformed.`,
+			Params: []Param{
+				{Name: "pattern", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "matches", Type: "[]string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				r0, err := filepath.Glob(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{r0}, nil
+			},
+		},
+		{
+			Name:      "isabs",
+			Package:   "path/filepath",
+			Signature: "func IsAbs(path string) bool",
+			Doc:       `IsAbs reports whether the path is absolute.`,
+			Params: []Param{
+				{Name: "path", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "bool", Type: "bool"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{filepath.IsAbs(args[0])}, nil
+			},
+		},
+		{
+			Name:      "join",
+			Package:   "path/filepath",
+			Signature: "func Join(elem ...string) string",
+			Doc: `Join joins any number of path elements into a single path,
+separating them with an OS specific [Separator]. Empty elements
+are ignored. The result is Cleaned. However, if the argument
+list is empty or all its elements are empty, Join returns
+an empty string.
+On Windows, the result will only be a UNC path if the first
+non-empty element is a UNC path.`,
+			Params: []Param{
+				{Name: "elem", Type: "...string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			Variadic: true,
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{filepath.Join(args[0:]...)}, nil
+			},
+		},
+		{
+			Name:      "rel",
+			Package:   "path/filepath",
+			Signature: "func Rel(basePath, targPath string) (string, error)",
+			Doc: `Rel returns a relative path that is lexically equivalent to targPath when
+joined to basePath with an intervening separator. That is,
+[Join](basePath, Rel(basePath, targPath)) is equivalent to targPath itself.
+
+The returned path will always be relative to basePath, even if basePath and
+targPath share no elements. Rel calls [Clean] on the result.
+
+An error is returned if targPath can't be made relative to basePath
+or if knowing the current working directory would be necessary to compute it.`,
+			Params: []Param{
+				{Name: "basePath", Type: "string"},
+				{Name: "targPath", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				r0, err := filepath.Rel(args[0], args[1])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{r0}, nil
+			},
+		},
+		{
+			Name:      "split",
+			Package:   "path/filepath",
+			Signature: "func Split(path string) (dir, file string)",
+			Doc: `Split splits path immediately following the final [Separator],
+separating it into a directory and file name component.
+If there is no Separator in path, Split returns an empty dir
+and file set to path.
+The returned values have the property that path = dir+file.`,
+			Params: []Param{
+				{Name: "path", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "dir", Type: "string"},
+				{Name: "file", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				r0, r1 := filepath.Split(args[0])
+				return []interface{}{r0, r1}, nil
+			},
+		},
+		{
+			Name:      "splitlist",
+			Package:   "path/filepath",
+			Signature: "func SplitList(path string) []string",
+			Doc: `SplitList splits a list of paths joined by the OS-specific [ListSeparator],
+usually found in PATH or GOPATH environment variables.
+Unlike strings.Split, SplitList returns an empty slice when passed an empty
+string.`,
+			Params: []Param{
+				{Name: "path", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "[]string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{filepath.SplitList(args[0])}, nil
+			},
+		},
+		{
+			Name:      "toslash",
+			Package:   "path/filepath",
+			Signature: "func ToSlash(path string) string",
+			Doc: `ToSlash returns the result of replacing each separator character
+in path with a slash ('/') character. Multiple separators are
+replaced by multiple slashes.`,
+			Params: []Param{
+				{Name: "path", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{filepath.ToSlash(args[0])}, nil
+			},
+		},
+		{
+			Name:      "volumename",
+			Package:   "path/filepath",
+			Signature: "func VolumeName(path string) string",
+			Doc: `VolumeName returns leading volume name.
+Given "C:\foo\bar" it returns "C:" on Windows.
+Given "\\host\share\foo" it returns "\\host\share".
+On other platforms it returns "".`,
+			Params: []Param{
+				{Name: "path", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{filepath.VolumeName(args[0])}, nil
+			},
+		},
+	})
+}
@@ -0,0 +1,170 @@
+// Code generated by gen.go from gonet.txt; DO NOT EDIT.
+
+package registry
+
+import "net"
+
+// The functions of package net wrapped by gonet.
+func init() {
+	register("gonet", []*Func{
+		{
+			Name:      "joinhostport",
+			Package:   "net",
+			Signature: "func JoinHostPort(host, port string) string",
+			Doc: `JoinHostPort combines host and port into a network address of the
+form "host:port". If host contains a colon, as found in literal
+IPv6 addresses, then JoinHostPort returns "[host]:port".
+
+See func Dial for a description of the host and port parameters.`,
+			Params: []Param{
+				{Name: "host", Type: "string"},
+				{Name: "port", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{net.JoinHostPort(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "lookupaddr",
+			Package:   "net",
+			Signature: "func LookupAddr(addr string) (names []string, err error)",
+			Doc: `LookupAddr performs a reverse lookup for the given address, returning a list
+of names mapping to that address.
+
+The returned names are validated to be properly formatted presentation-format
+domain names. If the response contains invalid names, those records are filtered
+out and an error will be returned alongside the remaining results, if any.
+
+When using the host C library resolver, at most one result will be
+returned. To bypass the host resolver, use a custom [Resolver].
+
+LookupAddr uses [context.Background] internally; to specify the context, use
+[Resolver.LookupAddr].`,
+			Params: []Param{
+				{Name: "addr", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "names", Type: "[]string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				r0, err := net.LookupAddr(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{r0}, nil
+			},
+		},
+		{
+			Name:      "lookupcname",
+			Package:   "net",
+			Signature: "func LookupCNAME(host string) (cname string, err error)",
+			Doc: `LookupCNAME returns the canonical name for the given host.
+Callers that do not care about the canonical name can call
+[LookupHost] or [LookupIP] directly; both take care of resolving
+the canonical name as part of the lookup.
+
+A canonical name is the final name after following zero
+or more CNAME records.
+LookupCNAME does not return an error if host does not
+contain DNS "CNAME" records, as long as host resolves to
+address records.
+
+The returned canonical name is validated to be a properly
+formatted presentation-format domain name.
+
+LookupCNAME uses [context.Background] internally; to specify the context, use
+[Resolver.LookupCNAME].`,
+			Params: []Param{
+				{Name: "host", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "cname", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				r0, err := net.LookupCNAME(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{r0}, nil
+			},
+		},
+		{
+			Name:      "lookuphost",
+			Package:   "net",
+			Signature: "func LookupHost(host string) (addrs []string, err error)",
+			Doc: `LookupHost looks up the given host using the local resolver.
+It returns a slice of that host's addresses.
+
+LookupHost uses [context.Background] internally; to specify the context, use
+[Resolver.LookupHost].`,
+			Params: []Param{
+				{Name: "host", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "addrs", Type: "[]string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				r0, err := net.LookupHost(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{r0}, nil
+			},
+		},
+		{
+			Name:      "lookuptxt",
+			Package:   "net",
+			Signature: "func LookupTXT(name string) ([]string, error)",
+			Doc: `LookupTXT returns the DNS TXT records for the given domain name.
+
+If a DNS TXT record holds multiple strings, they are concatenated as a
+single string.
+
+LookupTXT uses [context.Background] internally; to specify the context, use
+[Resolver.LookupTXT].`,
+			Params: []Param{
+				{Name: "name", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "[]string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				r0, err := net.LookupTXT(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{r0}, nil
+			},
+		},
+		{
+			Name:      "parsecidr",
+			Package:   "net",
+			Signature: "func ParseCIDR(s string) (IP, *IPNet, error)",
+			Doc: `ParseCIDR parses s as a CIDR notation IP address and prefix length,
+like "192.0.2.0/24" or "2001:db8::/32", as defined in
+RFC 4632 and RFC 4291.
+
+It returns the IP address and the network implied by the IP and
+prefix length.
+For example, ParseCIDR("192.0.2.1/24") returns the IP address
+192.0.2.1 and the network 192.0.2.0/24.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "ip", Type: "IP"},
+				{Name: "ipnet", Type: "*IPNet"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				r0, r1, err := net.ParseCIDR(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{r0, r1}, nil
+			},
+		},
+	})
+}
@@ -0,0 +1,958 @@
+// Code generated by gen.go from gostrings.txt; DO NOT EDIT.
+
+package registry
+
+import "strings"
+
+// The functions of package strings wrapped by gostrings.
+func init() {
+	register("gostrings", []*Func{
+		{
+			Name:      "clone",
+			Package:   "strings",
+			Signature: "func Clone(s string) string",
+			Doc: `Clone returns a fresh copy of s.
+It guarantees to make a copy of s into a new allocation,
+which can be important when retaining only a small substring
+of a much larger string. Using Clone can help such programs
+use less memory. Of course, since using Clone makes a copy,
+overuse of Clone can make programs use more memory.
+Clone should typically be used only rarely, and only when
+profiling indicates that it is needed.
+For strings of length zero the string "" will be returned
+and no allocation is made.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{stringsClone(args[0])}, nil
+			},
+		},
+		{
+			Name:      "compare",
+			Package:   "strings",
+			Signature: "func Compare(a, b string) int",
+			Doc: `Compare returns an integer comparing two strings lexicographically.
+The result will be 0 if a == b, -1 if a < b, and +1 if a > b.
+
+Use Compare when you need to perform a three-way comparison (with
+[slices.SortFunc], for example). It is usually clearer and always faster
+to use the built-in string comparison operators ==, <, >, and so on.`,
+			Params: []Param{
+				{Name: "a", Type: "string"},
+				{Name: "b", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "int", Type: "int"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.Compare(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "contains",
+			Package:   "strings",
+			Signature: "func Contains(s, substr string) bool",
+			Doc:       `Contains reports whether substr is within s.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "substr", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "bool", Type: "bool"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.Contains(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "containsany",
+			Package:   "strings",
+			Signature: "func ContainsAny(s, chars string) bool",
+			Doc:       `ContainsAny reports whether any Unicode code points in chars are within s.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "chars", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "bool", Type: "bool"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.ContainsAny(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "containsfunc",
+			Package:   "strings",
+			Signature: "func ContainsFunc(s string, f func(rune) bool) bool",
+			Doc: `ContainsFunc reports whether any Unicode code points r within s satisfy f(r).
+It stops as soon as a call to f returns true.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "f", Type: "func(rune) bool"},
+			},
+			Results: []Param{
+				{Name: "bool", Type: "bool"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				a1, err := ParsePredicate(args[1])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{stringsContainsFunc(args[0], a1)}, nil
+			},
+		},
+		{
+			Name:      "containsrune",
+			Package:   "strings",
+			Signature: "func ContainsRune(s string, r rune) bool",
+			Doc:       `ContainsRune reports whether the Unicode code point r is within s.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "r", Type: "rune"},
+			},
+			Results: []Param{
+				{Name: "bool", Type: "bool"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				a1, err := parseRune(args[1])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{strings.ContainsRune(args[0], a1)}, nil
+			},
+		},
+		{
+			Name:      "count",
+			Package:   "strings",
+			Signature: "func Count(s, substr string) int",
+			Doc: `Count counts the number of non-overlapping instances of substr in s.
+If substr is an empty string, Count returns 1 + the number of Unicode code points in s.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "substr", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "int", Type: "int"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.Count(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "cut",
+			Package:   "strings",
+			Signature: "func Cut(s, sep string) (before, after string, found bool)",
+			Doc: `Cut slices s around the first instance of sep,
+returning the text before and after sep.
+The found result reports whether sep appears in s.
+If sep does not appear in s, cut returns s, "", false.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "sep", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "before", Type: "string"},
+				{Name: "after", Type: "string"},
+				{Name: "found", Type: "bool"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				r0, r1, r2 := stringsCut(args[0], args[1])
+				return []interface{}{r0, r1, r2}, nil
+			},
+		},
+		{
+			Name:      "cutlast",
+			Package:   "strings",
+			Signature: "func CutLast(s, sep string) (before, after string, found bool)",
+			Doc: `CutLast slices s around the last instance of sep,
+returning the text before and after sep.
+The found result reports whether sep appears in s.
+If sep does not appear in s, CutLast returns s, "", false.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "sep", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "before", Type: "string"},
+				{Name: "after", Type: "string"},
+				{Name: "found", Type: "bool"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				r0, r1, r2 := stringsCutLast(args[0], args[1])
+				return []interface{}{r0, r1, r2}, nil
+			},
+		},
+		{
+			Name:      "cutprefix",
+			Package:   "strings",
+			Signature: "func CutPrefix(s, prefix string) (after string, found bool)",
+			Doc: `CutPrefix returns s without the provided leading prefix string
+and reports whether it found the prefix.
+If s doesn't start with prefix, CutPrefix returns s, false.
+If prefix is the empty string, CutPrefix returns s, true.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "prefix", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "after", Type: "string"},
+				{Name: "found", Type: "bool"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				r0, r1 := stringsCutPrefix(args[0], args[1])
+				return []interface{}{r0, r1}, nil
+			},
+		},
+		{
+			Name:      "cutsuffix",
+			Package:   "strings",
+			Signature: "func CutSuffix(s, suffix string) (before string, found bool)",
+			Doc: `CutSuffix returns s without the provided ending suffix string
+and reports whether it found the suffix.
+If s doesn't end with suffix, CutSuffix returns s, false.
+If suffix is the empty string, CutSuffix returns s, true.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "suffix", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "before", Type: "string"},
+				{Name: "found", Type: "bool"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				r0, r1 := stringsCutSuffix(args[0], args[1])
+				return []interface{}{r0, r1}, nil
+			},
+		},
+		{
+			Name:      "equalfold",
+			Package:   "strings",
+			Signature: "func EqualFold(s, t string) bool",
+			Doc: `EqualFold reports whether s and t, interpreted as UTF-8 strings,
+are equal under simple Unicode case-folding, which is a more general
+form of case-insensitivity.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "t", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "bool", Type: "bool"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.EqualFold(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "fields",
+			Package:   "strings",
+			Signature: "func Fields(s string) []string",
+			Doc: `Fields splits the string s around each instance of one or more consecutive white space
+characters, as defined by [unicode.IsSpace], returning a slice of substrings of s or an
+empty slice if s contains only white space. Every element of the returned slice is
+non-empty. Unlike [Split], leading and trailing runs of white space characters
+are discarded.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "[]string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.Fields(args[0])}, nil
+			},
+		},
+		{
+			Name:      "fieldsfunc",
+			Package:   "strings",
+			Signature: "func FieldsFunc(s string, f func(rune) bool) []string",
+			Doc: `FieldsFunc splits the string s at each run of Unicode code points c satisfying f(c)
+and returns an array of slices of s. If all code points in s satisfy f(c) or the
+string is empty, an empty slice is returned. Every element of the returned slice is
+non-empty. Unlike [Split], leading and trailing runs of code points satisfying f(c)
+are discarded.
+
+FieldsFunc makes no guarantees about the order in which it calls f(c)
+and assumes that f always returns the same value for a given c.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "f", Type: "func(rune) bool"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "[]string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				a1, err := ParsePredicate(args[1])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{strings.FieldsFunc(args[0], a1)}, nil
+			},
+		},
+		{
+			Name:      "hasprefix",
+			Package:   "strings",
+			Signature: "func HasPrefix(s, prefix string) bool",
+			Doc:       `HasPrefix reports whether the string s begins with prefix.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "prefix", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "bool", Type: "bool"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.HasPrefix(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "hassuffix",
+			Package:   "strings",
+			Signature: "func HasSuffix(s, suffix string) bool",
+			Doc:       `HasSuffix reports whether the string s ends with suffix.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "suffix", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "bool", Type: "bool"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.HasSuffix(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "index",
+			Package:   "strings",
+			Signature: "func Index(s, substr string) int",
+			Doc:       `Index returns the index of the first instance of substr in s, or -1 if substr is not present in s.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "substr", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "int", Type: "int"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.Index(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "indexany",
+			Package:   "strings",
+			Signature: "func IndexAny(s, chars string) int",
+			Doc: `IndexAny returns the index of the first instance of any Unicode code point
+from chars in s, or -1 if no Unicode code point from chars is present in s.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "chars", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "int", Type: "int"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.IndexAny(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "indexbyte",
+			Package:   "strings",
+			Signature: "func IndexByte(s string, c byte) int",
+			Doc:       `IndexByte returns the index of the first instance of c in s, or -1 if c is not present in s.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "c", Type: "byte"},
+			},
+			Results: []Param{
+				{Name: "int", Type: "int"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				a1, err := parseByte(args[1])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{strings.IndexByte(args[0], a1)}, nil
+			},
+		},
+		{
+			Name:      "indexfunc",
+			Package:   "strings",
+			Signature: "func IndexFunc(s string, f func(rune) bool) int",
+			Doc: `IndexFunc returns the index into s of the first Unicode
+code point satisfying f(c), or -1 if none do.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "f", Type: "func(rune) bool"},
+			},
+			Results: []Param{
+				{Name: "int", Type: "int"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				a1, err := ParsePredicate(args[1])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{strings.IndexFunc(args[0], a1)}, nil
+			},
+		},
+		{
+			Name:      "indexrune",
+			Package:   "strings",
+			Signature: "func IndexRune(s string, r rune) int",
+			Doc: `IndexRune returns the index of the first instance of the Unicode code point
+r, or -1 if rune is not present in s.
+If r is [utf8.RuneError], it returns the first instance of any
+invalid UTF-8 byte sequence.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "r", Type: "rune"},
+			},
+			Results: []Param{
+				{Name: "int", Type: "int"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				a1, err := parseRune(args[1])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{strings.IndexRune(args[0], a1)}, nil
+			},
+		},
+		{
+			Name:      "lastindex",
+			Package:   "strings",
+			Signature: "func LastIndex(s, substr string) int",
+			Doc:       `LastIndex returns the index of the last instance of substr in s, or -1 if substr is not present in s.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "substr", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "int", Type: "int"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.LastIndex(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "lastindexany",
+			Package:   "strings",
+			Signature: "func LastIndexAny(s, chars string) int",
+			Doc: `LastIndexAny returns the index of the last instance of any Unicode code
+point from chars in s, or -1 if no Unicode code point from chars is
+present in s.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "chars", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "int", Type: "int"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.LastIndexAny(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "lastindexbyte",
+			Package:   "strings",
+			Signature: "func LastIndexByte(s string, c byte) int",
+			Doc:       `LastIndexByte returns the index of the last instance of c in s, or -1 if c is not present in s.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "c", Type: "byte"},
+			},
+			Results: []Param{
+				{Name: "int", Type: "int"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				a1, err := parseByte(args[1])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{strings.LastIndexByte(args[0], a1)}, nil
+			},
+		},
+		{
+			Name:      "lastindexfunc",
+			Package:   "strings",
+			Signature: "func LastIndexFunc(s string, f func(rune) bool) int",
+			Doc: `LastIndexFunc returns the index into s of the last
+Unicode code point satisfying f(c), or -1 if none do.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "f", Type: "func(rune) bool"},
+			},
+			Results: []Param{
+				{Name: "int", Type: "int"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				a1, err := ParsePredicate(args[1])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{strings.LastIndexFunc(args[0], a1)}, nil
+			},
+		},
+		{
+			Name:      "map",
+			Package:   "strings",
+			Signature: "func Map(mapping func(rune) rune, s string) string",
+			Doc: `Map returns a copy of the string s with all its characters modified
+according to the mapping function. If mapping returns a negative value, the character is
+dropped from the string with no replacement.`,
+			Params: []Param{
+				{Name: "mapping", Type: "func(rune) rune"},
+				{Name: "s", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				a0, err := parseMapping(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{strings.Map(a0, args[1])}, nil
+			},
+		},
+		{
+			Name:      "repeat",
+			Package:   "strings",
+			Signature: "func Repeat(s string, count int) string",
+			Doc: `Repeat returns a new string consisting of count copies of the string s.
+
+It panics if count is negative or if the result of (len(s) * count)
+overflows.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "count", Type: "int"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				a1, err := parseInt(args[1])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{strings.Repeat(args[0], a1)}, nil
+			},
+		},
+		{
+			Name:      "replace",
+			Package:   "strings",
+			Signature: "func Replace(s, old, new string, n int) string",
+			Doc: `Replace returns a copy of the string s with the first n
+non-overlapping instances of old replaced by new.
+If old is empty, it matches at the beginning of the string
+and after each UTF-8 sequence, yielding up to k+1 replacements
+for a k-rune string.
+If n < 0, there is no limit on the number of replacements.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "old", Type: "string"},
+				{Name: "new", Type: "string"},
+				{Name: "n", Type: "int"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				a3, err := parseInt(args[3])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{strings.Replace(args[0], args[1], args[2], a3)}, nil
+			},
+		},
+		{
+			Name:      "replaceall",
+			Package:   "strings",
+			Signature: "func ReplaceAll(s, old, new string) string",
+			Doc: `ReplaceAll returns a copy of the string s with all
+non-overlapping instances of old replaced by new.
+If old is empty, it matches at the beginning of the string
+and after each UTF-8 sequence, yielding up to k+1 replacements
+for a k-rune string.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "old", Type: "string"},
+				{Name: "new", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.ReplaceAll(args[0], args[1], args[2])}, nil
+			},
+		},
+		{
+			Name:      "split",
+			Package:   "strings",
+			Signature: "func Split(s, sep string) []string",
+			Doc: `Split slices s into all substrings separated by sep and returns a slice of
+the substrings between those separators.
+
+If s does not contain sep and sep is not empty, Split returns a
+slice of length 1 whose only element is s.
+
+If sep is empty, Split splits after each UTF-8 sequence. If both s
+and sep are empty, Split returns an empty slice.
+
+It is equivalent to [SplitN] with a count of -1.
+
+To split around the first instance of a separator, see [Cut].`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "sep", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "[]string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.Split(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "splitafter",
+			Package:   "strings",
+			Signature: "func SplitAfter(s, sep string) []string",
+			Doc: `SplitAfter slices s into all substrings after each instance of sep and
+returns a slice of those substrings.
+
+If s does not contain sep and sep is not empty, SplitAfter returns
+a slice of length 1 whose only element is s.
+
+If sep is empty, SplitAfter splits after each UTF-8 sequence. If
+both s and sep are empty, SplitAfter returns an empty slice.
+
+It is equivalent to [SplitAfterN] with a count of -1.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "sep", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "[]string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.SplitAfter(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "splitaftern",
+			Package:   "strings",
+			Signature: "func SplitAfterN(s, sep string, n int) []string",
+			Doc: `SplitAfterN slices s into substrings after each instance of sep and
+returns a slice of those substrings.
+
+The count determines the number of substrings to return:
+  - n > 0: at most n substrings; the last substring will be the unsplit remainder;
+  - n == 0: the result is nil (zero substrings);
+  - n < 0: all substrings.
+
+Edge cases for s and sep (for example, empty strings) are handled
+as described in the documentation for [SplitAfter].`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "sep", Type: "string"},
+				{Name: "n", Type: "int"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "[]string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				a2, err := parseInt(args[2])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{strings.SplitAfterN(args[0], args[1], a2)}, nil
+			},
+		},
+		{
+			Name:      "splitn",
+			Package:   "strings",
+			Signature: "func SplitN(s, sep string, n int) []string",
+			Doc: `SplitN slices s into substrings separated by sep and returns a slice of
+the substrings between those separators.
+
+The count determines the number of substrings to return:
+  - n > 0: at most n substrings; the last substring will be the unsplit remainder;
+  - n == 0: the result is nil (zero substrings);
+  - n < 0: all substrings.
+
+Edge cases for s and sep (for example, empty strings) are handled
+as described in the documentation for [Split].
+
+To split around the first instance of a separator, see [Cut].`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "sep", Type: "string"},
+				{Name: "n", Type: "int"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "[]string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				a2, err := parseInt(args[2])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{strings.SplitN(args[0], args[1], a2)}, nil
+			},
+		},
+		{
+			Name:      "title",
+			Package:   "strings",
+			Signature: "func Title(s string) string",
+			Doc: `Title returns a copy of the string s with all Unicode letters that begin words
+mapped to their Unicode title case.
+
+Deprecated: The rule Title uses for word boundaries does not handle Unicode
+punctuation properly. Use golang.org/x/text/cases instead.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.Title(args[0])}, nil
+			},
+		},
+		{
+			Name:      "tolower",
+			Package:   "strings",
+			Signature: "func ToLower(s string) string",
+			Doc:       `ToLower returns s with all Unicode letters mapped to their lower case.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.ToLower(args[0])}, nil
+			},
+		},
+		{
+			Name:      "totitle",
+			Package:   "strings",
+			Signature: "func ToTitle(s string) string",
+			Doc: `ToTitle returns a copy of the string s with all Unicode letters mapped to
+their Unicode title case.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.ToTitle(args[0])}, nil
+			},
+		},
+		{
+			Name:      "toupper",
+			Package:   "strings",
+			Signature: "func ToUpper(s string) string",
+			Doc:       `ToUpper returns s with all Unicode letters mapped to their upper case.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.ToUpper(args[0])}, nil
+			},
+		},
+		{
+			Name:      "tovalidutf8",
+			Package:   "strings",
+			Signature: "func ToValidUTF8(s, replacement string) string",
+			Doc: `ToValidUTF8 returns a copy of the string s with each run of invalid UTF-8 byte sequences
+replaced by the replacement string, which may be empty.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "replacement", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.ToValidUTF8(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "trim",
+			Package:   "strings",
+			Signature: "func Trim(s, cutset string) string",
+			Doc: `Trim returns a slice of the string s with all leading and
+trailing Unicode code points contained in cutset removed.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "cutset", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.Trim(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "trimfunc",
+			Package:   "strings",
+			Signature: "func TrimFunc(s string, f func(rune) bool) string",
+			Doc: `TrimFunc returns a slice of the string s with all leading
+and trailing Unicode code points c satisfying f(c) removed.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "f", Type: "func(rune) bool"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				a1, err := ParsePredicate(args[1])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{strings.TrimFunc(args[0], a1)}, nil
+			},
+		},
+		{
+			Name:      "trimleft",
+			Package:   "strings",
+			Signature: "func TrimLeft(s, cutset string) string",
+			Doc: `TrimLeft returns a slice of the string s with all leading
+Unicode code points contained in cutset removed.
+
+To remove a prefix, use [TrimPrefix] instead.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "cutset", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.TrimLeft(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "trimleftfunc",
+			Package:   "strings",
+			Signature: "func TrimLeftFunc(s string, f func(rune) bool) string",
+			Doc: `TrimLeftFunc returns a slice of the string s with all leading
+Unicode code points c satisfying f(c) removed.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "f", Type: "func(rune) bool"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				a1, err := ParsePredicate(args[1])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{strings.TrimLeftFunc(args[0], a1)}, nil
+			},
+		},
+		{
+			Name:      "trimprefix",
+			Package:   "strings",
+			Signature: "func TrimPrefix(s, prefix string) string",
+			Doc: `TrimPrefix returns s without the provided leading prefix string.
+If s doesn't start with prefix, s is returned unchanged.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "prefix", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.TrimPrefix(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "trimright",
+			Package:   "strings",
+			Signature: "func TrimRight(s, cutset string) string",
+			Doc: `TrimRight returns a slice of the string s, with all trailing
+Unicode code points contained in cutset removed.
+
+To remove a suffix, use [TrimSuffix] instead.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "cutset", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.TrimRight(args[0], args[1])}, nil
+			},
+		},
+		{
+			Name:      "trimrightfunc",
+			Package:   "strings",
+			Signature: "func TrimRightFunc(s string, f func(rune) bool) string",
+			Doc: `TrimRightFunc returns a slice of the string s with all trailing
+Unicode code points c satisfying f(c) removed.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "f", Type: "func(rune) bool"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				a1, err := ParsePredicate(args[1])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{strings.TrimRightFunc(args[0], a1)}, nil
+			},
+		},
+		{
+			Name:      "trimspace",
+			Package:   "strings",
+			Signature: "func TrimSpace(s string) string",
+			Doc: `TrimSpace returns a slice (substring) of the string s,
+with all leading and trailing white space removed,
+as defined by Unicode.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.TrimSpace(args[0])}, nil
+			},
+		},
+		{
+			Name:      "trimsuffix",
+			Package:   "strings",
+			Signature: "func TrimSuffix(s, suffix string) string",
+			Doc: `TrimSuffix returns s without the provided trailing suffix string.
+If s doesn't end with suffix, s is returned unchanged.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+				{Name: "suffix", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{strings.TrimSuffix(args[0], args[1])}, nil
+			},
+		},
+	})
+}
@@ -0,0 +1,138 @@
+// Code generated by gen.go from gourl.txt; DO NOT EDIT.
+
+package registry
+
+import "net/url"
+
+// The functions of package url wrapped by gourl.
+func init() {
+	register("gourl", []*Func{
+		{
+			Name:      "parse",
+			Package:   "net/url",
+			Signature: "func Parse(rawURL string) (*URL, error)",
+			Doc: `Parse parses a raw url into a [URL] structure.
+
+The url may be relative (a path, without a host) or absolute
+(starting with a scheme). Trying to parse a hostname and path
+without a scheme is invalid but may not necessarily return an
+error, due to parsing ambiguities.`,
+			Params: []Param{
+				{Name: "rawURL", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "url", Type: "*URL"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				r0, err := url.Parse(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{r0}, nil
+			},
+		},
+		{
+			Name:      "parserequesturi",
+			Package:   "net/url",
+			Signature: "func ParseRequestURI(rawURL string) (*URL, error)",
+			Doc: `ParseRequestURI parses a raw url into a [URL] structure. It assumes that
+url was received in an HTTP request, so the url is interpreted
+only as an absolute URI or an absolute path.
+The string url is assumed not to have a #fragment suffix.
+(Web browsers strip #fragment before sending the URL to a web server.)`,
+			Params: []Param{
+				{Name: "rawURL", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "url", Type: "*URL"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				r0, err := url.ParseRequestURI(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{r0}, nil
+			},
+		},
+		{
+			Name:      "pathescape",
+			Package:   "net/url",
+			Signature: "func PathEscape(s string) string",
+			Doc: `PathEscape escapes the string so it can be safely placed inside a [URL] path segment,
+replacing special characters (including /) with %XX sequences as needed.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{url.PathEscape(args[0])}, nil
+			},
+		},
+		{
+			Name:      "pathunescape",
+			Package:   "net/url",
+			Signature: "func PathUnescape(s string) (string, error)",
+			Doc: `PathUnescape does the inverse transformation of [PathEscape],
+converting each 3-byte encoded substring of the form "%AB" into the
+hex-decoded byte 0xAB. It returns an error if any % is not followed
+by two hexadecimal digits.
+
+PathUnescape is identical to [QueryUnescape] except that it does not
+unescape '+' to ' ' (space).`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				r0, err := url.PathUnescape(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{r0}, nil
+			},
+		},
+		{
+			Name:      "queryescape",
+			Package:   "net/url",
+			Signature: "func QueryEscape(s string) string",
+			Doc: `QueryEscape escapes the string so it can be safely placed
+inside a [URL] query.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				return []interface{}{url.QueryEscape(args[0])}, nil
+			},
+		},
+		{
+			Name:      "queryunescape",
+			Package:   "net/url",
+			Signature: "func QueryUnescape(s string) (string, error)",
+			Doc: `QueryUnescape does the inverse transformation of [QueryEscape],
+converting each 3-byte encoded substring of the form "%AB" into the
+hex-decoded byte 0xAB.
+It returns an error if any % is not followed by two hexadecimal
+digits.`,
+			Params: []Param{
+				{Name: "s", Type: "string"},
+			},
+			Results: []Param{
+				{Name: "string", Type: "string"},
+			},
+			fn: func(args []string) ([]interface{}, error) {
+				r0, err := url.QueryUnescape(args[0])
+				if err != nil {
+					return nil, err
+				}
+				return []interface{}{r0}, nil
+			},
+		},
+	})
+}
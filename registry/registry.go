@@ -0,0 +1,213 @@
+// Package registry lists the Go functions wrapped by gosh, with the mapping
+// of their command line arguments to parameters that the gosh commands are
+// built on.
+//
+// Functions are called with string arguments, converted to the types of their
+// parameters as on the command line:
+//
+//	f := registry.Lookup("gostrings", "split")
+//	results, err := f.Invoke([]string{"a,b", ","})
+//	// results[0] is []string{"a", "b"}
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+//go:generate go run gen.go -pkg strings -group gostrings
+//go:generate go run gen.go -pkg path/filepath -group gofilepath
+//go:generate go run gen.go -pkg net -group gonet
+//go:generate go run gen.go -pkg net/url -group gourl
+
+// Func describes a wrapped Go function.
+type Func struct {
+	// Name is the name of the function on the command line, its lower-cased
+	// Go name.
+	Name string
+
+	// Group is the command group of the function, such as gostrings.
+	Group string
+
+	// Package is the import path of the package declaring the function.
+	Package string
+
+	// Signature is the Go declaration of the function, such as
+	// "func Split(s, sep string) []string".
+	Signature string
+
+	// Doc is the doc comment of the function.
+	Doc string
+
+	Params  []Param
+	Results []Param
+
+	// Variadic is set if the last parameter is variadic.
+	Variadic bool
+
+	// VariadicStdin is set if f is variadic but its first argument, a
+	// string, may still be read from stdin, as for replacer.
+	VariadicStdin bool
+
+	// fn calls the function with args converted to its parameter types and
+	// returns its results, without the trailing error if any.
+	fn func(args []string) ([]interface{}, error)
+}
+
+// Param is a parameter or a result of a Func. Types are written as in Go,
+// relative to the package of the function, with ... for variadic parameters.
+type Param struct {
+	Name string
+	Type string
+}
+
+// Shape is the shape of the results of a Func.
+type Shape int
+
+const (
+	ShapeNone   Shape = iota // no result but possibly an error
+	ShapeBool                // a single bool
+	ShapeValue               // a single value other than a bool or a slice
+	ShapeList                // a single slice
+	ShapeRecord              // several values
+)
+
+var shapeNames = [...]string{"none", "bool", "value", "list", "record"}
+
+func (s Shape) String() string {
+	if s < 0 || int(s) >= len(shapeNames) {
+		return fmt.Sprintf("Shape(%d)", int(s))
+	}
+	return shapeNames[s]
+}
+
+// Arity returns the minimum and maximum number of arguments of f. max is -1
+// if f is variadic.
+func (f *Func) Arity() (min, max int) {
+	if f.Variadic {
+		return len(f.Params) - 1, -1
+	}
+	return len(f.Params), len(f.Params)
+}
+
+// ParamTypes returns the types of the parameters of f.
+func (f *Func) ParamTypes() []string {
+	types := make([]string, len(f.Params))
+	for i, p := range f.Params {
+		types[i] = p.Type
+	}
+	return types
+}
+
+// Shape returns the shape of the results of f.
+func (f *Func) Shape() Shape {
+	switch {
+	case len(f.Results) == 0:
+		return ShapeNone
+	case len(f.Results) > 1:
+		return ShapeRecord
+	case f.Results[0].Type == "bool":
+		return ShapeBool
+	case strings.HasPrefix(f.Results[0].Type, "[]"):
+		return ShapeList
+	}
+	return ShapeValue
+}
+
+// Stdin reports whether the first argument of f may be read from stdin on
+// the command line: it is a string, and f is not variadic unless
+// VariadicStdin is set.
+func (f *Func) Stdin() bool {
+	return len(f.Params) > 0 && f.Params[0].Type == "string" && (!f.Variadic || f.VariadicStdin)
+}
+
+// Invoke calls f with args converted to its parameter types. It returns the
+// results of f, without its trailing error which is returned instead.
+func (f *Func) Invoke(args []string) ([]interface{}, error) {
+	min, max := f.Arity()
+	if len(args) < min || max >= 0 && len(args) > max {
+		return nil, &ArityError{Func: f, Args: len(args)}
+	}
+	return f.fn(args)
+}
+
+// WithFunc returns a copy of f calling fn in place of the function it
+// describes, such as an emulation of it. fn is called with as many arguments
+// as f takes, and returns its results as Invoke does.
+func (f *Func) WithFunc(fn func(args []string) ([]interface{}, error)) *Func {
+	g := *f
+	g.fn = fn
+	return &g
+}
+
+// ArityError is returned by Invoke when called with the wrong number of
+// arguments.
+type ArityError struct {
+	Func *Func
+	Args int
+}
+
+func (e *ArityError) Error() string {
+	min, max := e.Func.Arity()
+	if max < 0 {
+		return fmt.Sprintf("%s takes at least %d argument(s), got %d", e.Func.Name, min, e.Args)
+	}
+	return fmt.Sprintf("%s takes %d argument(s), got %d", e.Func.Name, min, e.Args)
+}
+
+// groups maps group names to their functions, sorted by name.
+var groups = make(map[string][]*Func)
+
+// register adds the functions of group.
+func register(group string, funcs []*Func) {
+	for _, f := range funcs {
+		f.Group = group
+	}
+	groups[group] = append(groups[group], funcs...)
+	sort.Slice(groups[group], func(i, j int) bool {
+		return groups[group][i].Name < groups[group][j].Name
+	})
+}
+
+// Register adds funcs to group. It registers the functions of the commands
+// that are written by hand rather than generated, such as gostrings join,
+// which are built with WithFunc.
+func Register(group string, funcs ...*Func) {
+	register(group, funcs)
+}
+
+// Groups returns the names of the groups, sorted.
+func Groups() []string {
+	names := make([]string, 0, len(groups))
+	for g := range groups {
+		names = append(names, g)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Funcs returns the functions of group, sorted by name, or nil if there is no
+// such group. The Funcs are shared and must not be modified.
+func Funcs(group string) []*Func {
+	return append([]*Func(nil), groups[group]...)
+}
+
+// All returns the functions of every group, sorted by group and name.
+func All() []*Func {
+	var all []*Func
+	for _, g := range Groups() {
+		all = append(all, groups[g]...)
+	}
+	return all
+}
+
+// Lookup returns the function name of group, or nil if there is none.
+func Lookup(group, name string) *Func {
+	for _, f := range groups[group] {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aca/gosh/registry"
+	"github.com/aca/gosh/utils"
+	"github.com/spf13/cobra"
+)
+
+const replLong = `Repl reads gosh commands from a line editor and runs them in-process, showing
+their result right away. The gosh prefix of commands is optional:
+
+  gosh> gourl parse https://x/y?z=1
+  {"Scheme":"https","Opaque":"","User":null,"Host":"x","Path":"/y",...}
+  gosh> gostrings hasprefix $_ '{'
+  true
+
+$_ is the output of the last command, without its trailing newline. It is
+also the stdin of every command, so that gostrings split , splits the last
+result. Words are quoted as in gosh pipe: Go string literals in double quotes
+or back quotes, raw strings in single quotes; $_ is not expanded in quotes.
+
+Tab completes command names and flags, and arrow keys move through the
+line and its history. exit, quit or ^D leave the repl.`
+
+func newReplCommand(root *cobra.Command) *cobra.Command {
+	return &cobra.Command{
+		Use:   "repl",
+		Short: "Run commands interactively",
+		Long:  replLong,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r := &repl{
+				root:   root,
+				out:    cmd.OutOrStdout(),
+				errOut: cmd.ErrOrStderr(),
+			}
+			return r.run(cmd.InOrStdin())
+		},
+	}
+}
+
+// repl runs the commands of root read from a terminal, or one per line from
+// any other input.
+type repl struct {
+	root        *cobra.Command
+	out, errOut io.Writer
+
+	// last is the output of the last successful command, $_.
+	last string
+}
+
+func (r *repl) run(in io.Reader) error {
+	readLine := bufio.NewReader(in)
+	next := func() (string, error) {
+		line, err := readLine.ReadString('\n')
+		if err == io.EOF && line != "" {
+			err = nil
+		}
+		return strings.TrimSuffix(line, "\n"), err
+	}
+
+	// Commands change the output of root while they run, so the terminal is
+	// looked up once beforehand.
+	if f, ok := in.(*os.File); ok && isTerminal(int(f.Fd())) {
+		ed := &lineEditor{
+			fd:       int(f.Fd()),
+			in:       readLine,
+			out:      r.out,
+			prompt:   "gosh> ",
+			complete: r.complete,
+		}
+		next = ed.readLine
+	}
+
+	for {
+		line, err := next()
+		switch {
+		case err == io.EOF:
+			return nil
+		case errors.Is(err, errInterrupt):
+			continue
+		case err != nil:
+			return err
+		}
+
+		words, err := utils.SplitWords(line, r.expand)
+		if err != nil {
+			fmt.Fprintln(r.errOut, "Error:", err)
+			continue
+		}
+		if len(words) > 0 && words[0] == "gosh" {
+			words = words[1:]
+		}
+		if len(words) == 0 {
+			continue
+		}
+		switch words[0] {
+		case "exit", "quit":
+			return nil
+		case "repl":
+			fmt.Fprintln(r.errOut, "Error: already in the repl")
+			continue
+		}
+
+		r.exec(words)
+	}
+}
+
+// expand replaces $_ in a bare word with the last result.
+func (r *repl) expand(word string) string {
+	return strings.ReplaceAll(word, "$_", r.last)
+}
+
+// exec runs a command and prints its result, on lines of its own. Boolean
+// results, which commands report through their exit status, are printed as
+// true or false.
+func (r *repl) exec(args []string) {
+	var out bytes.Buffer
+	err := utils.ExecuteArgs(r.root, args, strings.NewReader(r.last), &out, r.errOut)
+
+	switch {
+	case errors.Is(err, utils.ErrFalse):
+		out.WriteString("false\n")
+	case err != nil:
+		utils.ReportError(r.errOut, err, "text")
+		return
+	case out.Len() == 0 && r.boolFunc(args):
+		out.WriteString("true\n")
+	}
+
+	r.last = strings.TrimSuffix(out.String(), "\n")
+	if r.last != "" {
+		fmt.Fprintln(r.out, r.last)
+	}
+}
+
+// boolFunc reports whether args run a function returning a single bool.
+func (r *repl) boolFunc(args []string) bool {
+	cmd, _, err := r.root.Find(args)
+	if err != nil {
+		return false
+	}
+	f := utils.FuncOf(cmd)
+	return f != nil && f.Shape() == registry.ShapeBool
+}
+
+// complete returns the completions of the last word of line: commands and
+// flags, or files where the command doesn't restrict its arguments.
+func (r *repl) complete(line string) ([]string, bool) {
+	words := strings.Fields(line)
+	toComplete := ""
+	if len(words) > 0 && !strings.HasSuffix(line, " ") {
+		toComplete = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+	if len(words) > 0 && words[0] == "gosh" {
+		words = words[1:]
+	}
+
+	var completions []string
+	if len(words) == 0 {
+		for _, w := range []string{"exit", "quit"} {
+			if strings.HasPrefix(w, toComplete) {
+				completions = append(completions, w)
+			}
+		}
+	}
+
+	comps, directive, err := utils.Complete(r.root, words, toComplete)
+	if err != nil {
+		return completions, true
+	}
+	completions = append(completions, comps...)
+
+	if len(completions) == 0 && directive&cobra.ShellCompDirectiveNoFileComp == 0 {
+		matches, _ := filepath.Glob(toComplete + "*")
+		for _, m := range matches {
+			if fi, err := os.Stat(m); err == nil && fi.IsDir() {
+				m += string(filepath.Separator)
+			}
+			completions = append(completions, m)
+		}
+		return completions, len(matches) == 1 && !strings.HasSuffix(completions[0], string(filepath.Separator))
+	}
+	return completions, directive&cobra.ShellCompDirectiveNoSpace == 0
+}
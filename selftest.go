@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aca/gosh/utils"
+	"github.com/spf13/cobra"
+)
+
+const selftestLong = `Selftest runs the examples shown in the help of every command, or of the
+commands of group, and checks their output and exit status. Examples don't
+depend on the network, so selftest can run anywhere gosh does. The config file
+is ignored.
+
+Failures are reported with the output they expected; -v reports successes
+too. Commands running a function without examples count as failures.`
+
+func newSelftestCommand(root *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "selftest [group]",
+		Short: "Run the examples of the commands",
+		Long:  selftestLong,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := cobra.MaximumNArgs(1)(cmd, args); err != nil {
+				return err
+			}
+			if len(args) == 1 && findGroup(root, args[0]) == nil {
+				return fmt.Errorf("unknown group %q", args[0])
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			verbose, err := cmd.Flags().GetBool("verbose")
+			if err != nil {
+				return err
+			}
+
+			// Examples show the built-in defaults.
+			utils.DisableConfig()
+
+			start := root
+			if len(args) == 1 {
+				start = findGroup(root, args[0])
+			}
+
+			t := &selftest{w: cmd.OutOrStdout(), verbose: verbose}
+			utils.RunExamples(root, start, t.report)
+			fmt.Fprintf(t.w, "%d passed, %d failed\n", t.passed, t.failed)
+			if t.failed > 0 {
+				return fmt.Errorf("%d of %d examples failed", t.failed, t.passed+t.failed)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolP("verbose", "v", false, "report passing examples")
+	return cmd
+}
+
+// findGroup returns the group of root named name, or nil.
+func findGroup(root *cobra.Command, name string) *cobra.Command {
+	for _, g := range groups {
+		if g.Name() == name && g.Parent() == root {
+			return g
+		}
+	}
+	return nil
+}
+
+// selftest reports the results of the examples of commands under root.
+type selftest struct {
+	w       io.Writer
+	verbose bool
+
+	passed, failed int
+}
+
+func (t *selftest) report(r utils.ExampleResult) {
+	if r.Missing {
+		t.failed++
+		fmt.Fprintf(t.w, "FAIL %s: no examples\n", strings.Join(r.Path, " "))
+		return
+	}
+
+	ex := r.Example
+	line := ex.CommandLine(strings.Join(r.Path, " "))
+	if r.OK() {
+		t.passed++
+		if t.verbose {
+			fmt.Fprintf(t.w, "ok   %s\n", line)
+		}
+		return
+	}
+
+	t.failed++
+	fmt.Fprintf(t.w, "FAIL %s\n", line)
+	fmt.Fprintf(t.w, "     want exit %d, output %q\n", ex.Exit, ex.Output)
+	fmt.Fprintf(t.w, "     got  exit %d, output %q\n", r.Exit, r.Output)
+	if r.Err != nil && r.Exit > utils.ExitFalse {
+		fmt.Fprintf(t.w, "     error: %v\n", r.Err)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/aca/gosh/utils"
+	"github.com/spf13/cobra"
+)
+
+const serveLong = `Serve answers requests read from stdin, one JSON object per line, by running
+gosh commands in-process. It saves the cost of starting a process per call to
+scripts running gosh in a loop, e.g. as a shell coprocess.
+
+Requests name the command as group.name and may give flags in args:
+
+  {"id": 1, "cmd": "gofilepath.rel", "args": ["a", "a/b"]}
+  {"id": 2, "cmd": "gostrings.split", "args": [",", "-o", "json"], "stdin": "a,b"}
+
+Each request is answered by one line, carrying the request id, the output of
+the command, its exit status and, if it failed, the error as printed by
+--error-format json:
+
+  {"id":1,"result":"b","exit":0}
+  {"id":2,"result":"[\"a\",\"b\"]\n","exit":0}`
+
+// serveRequest is a request read by gosh serve.
+type serveRequest struct {
+	ID    json.RawMessage `json:"id,omitempty"`
+	Cmd   string          `json:"cmd"`
+	Args  []string        `json:"args"`
+	Stdin string          `json:"stdin"`
+}
+
+func newServeCommand(root *cobra.Command) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve --stdio",
+		Short: "Answer JSON requests to run commands in-process",
+		Long:  serveLong,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			stdio, err := cmd.Flags().GetBool("stdio")
+			if err != nil {
+				return err
+			}
+			if !stdio {
+				return errors.New("serve only supports --stdio")
+			}
+			return serve(root, cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().Bool("stdio", false, "read requests from stdin and write responses to stdout")
+	return cmd
+}
+
+// serve answers the requests read from r until EOF.
+func serve(root *cobra.Command, r io.Reader, w io.Writer) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1<<30)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if err := enc.Encode(serveOne(root, line)); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+func serveOne(root *cobra.Command, line []byte) utils.Record {
+	var req serveRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		return serveResponse(nil, "", err)
+	}
+	if req.Cmd == "" {
+		return serveResponse(req.ID, "", errors.New("missing cmd"))
+	}
+
+	args := append(strings.Fields(strings.Replace(req.Cmd, ".", " ", -1)), req.Args...)
+	if c, _, err := root.Find(args); err == nil && !c.Runnable() {
+		return serveResponse(req.ID, "", fmt.Errorf("unknown command %q", req.Cmd))
+	}
+	out := &bytes.Buffer{}
+	err := utils.ExecuteArgs(root, args, strings.NewReader(req.Stdin), out, ioutil.Discard)
+	return serveResponse(req.ID, out.String(), err)
+}
+
+func serveResponse(id json.RawMessage, result string, err error) utils.Record {
+	r := utils.Record{}
+	if id != nil {
+		r = append(r, utils.Field{Name: "id", Value: id})
+	}
+	r = append(r,
+		utils.Field{Name: "result", Value: result},
+		utils.Field{Name: "exit", Value: utils.ExitCode(err)},
+	)
+	if utils.ExitCode(err) > utils.ExitFalse {
+		r = append(r, utils.Field{Name: "error", Value: utils.ErrorRecord(err)})
+	}
+	return r
+}
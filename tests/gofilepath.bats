@@ -22,3 +22,36 @@
   run gofilepath split "a/b.go"
   [ "$output" = "$(printf 'a/\nb.go\n')" ]
 }
+
+@test "base lines" {
+  [ "$(printf 'a/b.go\nc/d.txt\n' | gofilepath base -l)" = "$(printf 'b.go\nd.txt')" ]
+}
+
+@test "base null" {
+  [ "$(printf 'a/b\nc.go\0x/y z\0' | gofilepath -z base | tr '\0' '|')" = "$(printf 'b\nc.go|y z|')" ]
+}
+
+@test "split formats" {
+  [ "$(printf 'a/b.go\nc/d/e.txt\n' | gofilepath split -l -o csv)" = "$(printf 'dir,file\na/,b.go\nc/d/,e.txt')" ]
+  [ "$(gofilepath split -o yaml 'a/b.go')" = "$(printf 'dir: a/\nfile: b.go')" ]
+  [ "$(gofilepath split -o shell 'a b/c.go')" = "$(printf "dir='a b/'\nfile=c.go")" ]
+}
+
+@test "split template" {
+  [ "$(gofilepath split a/b.go -o 'template={{.dir}} {{.file | upper}}')" = "a/ B.GO" ]
+
+  printf '{{.file}}\n' > "$BATS_TMPDIR/split.tmpl"
+  [ "$(printf 'a/b\nc/d\n' | gofilepath split -l --template-file "$BATS_TMPDIR/split.tmpl")" = "$(printf 'b\nd')" ]
+
+  run gofilepath split a/b --template-file "$BATS_TMPDIR/split.tmpl" -o json
+  [ "$status" -eq 64 ]
+}
+
+@test "os windows" {
+  [ "$(gofilepath --os windows join 'C:\Program Files' app ../bin)" = 'C:\Program Files\bin' ]
+  [ "$(gofilepath --os windows volumename '\\host\share\setup.exe')" = '\\host\share' ]
+  gofilepath --os windows isabs 'C:\x'
+
+  run gofilepath --os windows abs x
+  [ "$status" -eq 64 ]
+}
@@ -1,16 +1,24 @@
 #!/usr/bin/env bats
 
+# Lookups are given IP addresses and invalid names, which are resolved without
+# querying DNS, so that the tests run offline.
+
 @test "lookupaddr" {
-  run gonet lookupaddr "8.8.8.8"
-  [ "$output" = 'dns.google.' ]
+  run gonet lookupaddr "not-an-ip"
+  [ "$status" -eq 68 ]
 }
 
 @test "lookuphost" {
-  run gonet lookuphost "google.com" -o json
-  [ "$output" = '["172.217.175.238","2404:6800:4004:811::200e"]' ]
+  run gonet lookuphost "192.0.2.1" -o json
+  [ "$output" = '["192.0.2.1"]' ]
 }
 
 @test "lookuptxt" {
-  run gonet lookuptxt "google.com" -o json
-  [ "$status" -eq 0 ]
+  run gonet lookuptxt ""
+  [ "$status" -eq 68 ]
+}
+
+@test "parsecidr" {
+  run gonet parsecidr "192.0.2.1/24" -o json
+  [ "$output" = '{"ip":"192.0.2.1","ipnet":{"IP":"192.0.2.0","Mask":"ffffff00"}}' ]
 }
@@ -0,0 +1,118 @@
+#!/usr/bin/env bats
+
+@test "serve" {
+  run gosh serve --stdio <<< '{"id":1,"cmd":"gofilepath.rel","args":["a","a/b"]}'
+  [ "$output" = '{"id":1,"result":"b","exit":0}' ]
+
+  run gosh serve --stdio <<< '{"cmd":"gostrings.split","args":[","],"stdin":"a,b"}'
+  [ "$output" = '{"result":"a\nb\n","exit":0}' ]
+}
+
+@test "pipe" {
+  [ "$(echo ' a,b ' | gosh pipe 'trimspace | split "," | toupper')" = "$(printf 'A\nB')" ]
+  [ "$(gosh pipe 'x/y' 'split "/" | gofilepath.join /root')" = "/root/x/y" ]
+  [ "$(printf 'a.go\nb.txt\n' | gosh pipe -l 'hassuffix .go')" = "a.go" ]
+  [ "$(echo ' a,b ' | gosh pipe 'trimspace | split "," | toupper | join "-"')" = "A-B" ]
+
+  run gosh pipe a/b 'split "/" | base'
+  [ "$status" -eq 64 ]
+}
+
+@test "repl" {
+  run gosh repl <<< $'gostrings toupper abc\ngostrings repeat $_ 2\ngostrings hasprefix $_ AB\nquit\ngostrings toupper no'
+  [ "$output" = "$(printf 'ABC\nABCABC\ntrue')" ]
+}
+
+@test "install-links" {
+  dir="$(mktemp -d)"
+  run gosh install-links "$dir"
+  [ "$status" -eq 0 ]
+  [ "$("$dir/gostrings" toupper abc)" = "ABC" ]
+  [ "$("$dir/gourl" queryescape 'a b')" = "a+b" ]
+
+  run gosh install-links "$dir"
+  [ "$status" -eq 2 ]
+  run gosh install-links -f "$dir"
+  [ "$status" -eq 0 ]
+  rm -r "$dir"
+}
+
+@test "selftest" {
+  run gosh selftest
+  [ "$status" -eq 0 ]
+
+  run gosh selftest gonet -v
+  [ "$status" -eq 0 ]
+  [ "${lines[0]}" = "ok   gonet joinhostport ::1 80" ]
+
+  run gosh selftest bogus
+  [ "$status" -eq 64 ]
+}
+
+@test "unknown command" {
+  run gosh gostrings nosuch
+  [ "$status" -eq 64 ]
+  [ "$output" = 'Error: unknown command "nosuch" for "gosh gostrings"' ]
+
+  run gosh nosuch
+  [ "$status" -eq 64 ]
+
+  run gosh gostrings
+  [ "$status" -eq 0 ]
+}
+
+@test "config" {
+  export XDG_CONFIG_HOME="$(mktemp -d)"
+  mkdir "$XDG_CONFIG_HOME/gosh"
+  cat > "$XDG_CONFIG_HOME/gosh/config" <<'CONFIG'
+output = "json"
+
+[gofilepath]
+output = "text"
+
+[alias]
+basename-noext = "gofilepath base | gostrings trimsuffix .go"
+CONFIG
+
+  [ "$(gosh gostrings split a,b ,)" = '["a","b"]' ]
+  [ "$(gosh gostrings split a,b , -o text)" = "$(printf 'a\nb')" ]
+  [ "$(gosh gofilepath split a/b)" = "$(printf 'a/\nb')" ]
+  [ "$(gosh gofilepath basename-noext /a/b.go)" = "b" ]
+
+  echo '[bogus]' >> "$XDG_CONFIG_HOME/gosh/config"
+  run gosh gostrings toupper a
+  [ "$status" -eq 78 ]
+  rm -r "$XDG_CONFIG_HOME"
+}
+
+@test "plugin" {
+  dir="$(mktemp -d)"
+  cat > "$dir/gosh-hello" <<'PLUGIN'
+#!/bin/sh
+if [ "$1" = "__complete" ]; then
+  printf 'world\n:4\n'
+  exit
+fi
+read -r line
+echo "hello $* $line"
+exit 3
+PLUGIN
+  chmod +x "$dir/gosh-hello"
+  export PATH="$dir:$PATH"
+
+  run gosh hello -x world <<< "stdin"
+  [ "$status" -eq 3 ]
+  [ "$output" = "hello -x world stdin" ]
+
+  gosh --help | grep -q "^  hello "
+  [ "$(gosh __complete hello w 2>/dev/null </dev/null | head -1)" = ":4" ]
+  [ "$(GOSH_COMPLETE_PLUGINS=other,hello gosh __complete hello w 2>/dev/null | head -1)" = "world" ]
+  rm -r "$dir"
+}
+
+@test "complete arguments and flag values" {
+  gosh __complete gostrings split a -o '' | grep -qx json
+  gosh __complete gourl parse '' | grep -qx 'https://'
+  gosh __complete gonet lookupaddr '' | grep -qx '127.0.0.1'
+  [ "$(gosh __complete gofilepath base '' | tail -1)" = ":0" ]
+}
@@ -75,3 +75,102 @@
 @test "repeat" {
   [ "$(echo -n 'a' | gostrings repeat 3)" = "aaa" ]
 }
+
+@test "trimspace lines" {
+  [ "$(printf ' hello \n world\n' | gostrings -l trimspace)" = "$(printf 'hello\nworld')" ]
+}
+
+@test "hasprefix lines" {
+  [ "$(printf 'chicken\negg\n' | gostrings hasprefix -l 'ch')" = "$(printf 'true\nfalse')" ]
+}
+
+@test "split null" {
+  [ "$(echo -n 'a,b' | gostrings split -z ',' | tr '\0' '|')" = "a|b|" ]
+}
+
+@test "exit codes" {
+  run gostrings repeat 'a' 'x'
+  [ "$status" -eq 65 ]
+
+  run gostrings repeat
+  [ "$status" -eq 64 ]
+
+  run gostrings repeat 'a' 'x' --error-format json
+  [ "$(echo "$output" | jq -r .fields.Num)" = "x" ]
+}
+
+@test "split go literal" {
+  [ "$(printf 'a\tb' | gostrings split -e '\t' -o json)" = '["a","b"]' ]
+  [ "$(gostrings -e replaceall 'a"b' '"' '\x41é')" = "aAéb" ]
+
+  sep="$(mktemp)"
+  printf '\n--\n' > "$sep"
+  [ "$(printf 'a\n--\nb' | gostrings split -e "@$sep" -o json)" = '["a","b"]' ]
+  rm "$sep"
+
+  [ "$(gostrings -e trimprefix '@@x' '@@')" = "x" ]
+
+  run gostrings -e split a '\q'
+  [ "$status" -eq 65 ]
+}
+
+@test "special case" {
+  [ "$(gostrings toupper --special-case tr istanbul)" = "İSTANBUL" ]
+  [ "$(gostrings tolower --special-case az DİYARBAKIR)" = "diyarbakır" ]
+  [ "$(gostrings title --title-mode first "don't panic")" = "Don't panic" ]
+}
+
+@test "join" {
+  [ "$(gostrings split a,b,c , | gostrings join ,)" = "a,b,c" ]
+  [ "$(gostrings split 'a b,c' , -o json | gostrings join --json ' ' --quote shell)" = "'a b' c" ]
+  [ "$(printf 'a\0b\0' | gostrings join -z + | tr -d '\0')" = "a+b" ]
+}
+
+@test "cut" {
+  [ "$(gostrings cut key=value = -o json)" = '{"before":"key","after":"value","found":true}' ]
+  run gostrings cut key =
+  [ "$output" = "$(printf 'key\n\nfalse')" ]
+}
+
+@test "func arguments" {
+  [ "$(gostrings trimfunc 123abc456 IsDigit)" = "abc" ]
+  [ "$(gostrings map ToUpper gopher)" = "GOPHER" ]
+  run gostrings trimfunc abc IsBogus
+  [ "$status" -eq 65 ]
+}
+
+@test "func flag" {
+  [ "$(echo 'Привет, world' | gostrings trimfunc --func Cyrillic)" = ", world" ]
+  [ "$(printf 'bell\a\n' | gostrings map --func '!IsPrint' --delete -l)" = "bell" ]
+  [ "$(gostrings map --func Han --replace '?' 'hello, 世界')" = "hello, ??" ]
+  run gostrings map --func Han x
+  [ "$status" -eq 64 ]
+}
+
+@test "case" {
+  [ "$(gostrings case snake parseHTTPRequestID)" = "parse_http_request_id" ]
+  [ "$(printf 'user_id\nHTTPServer\n' | gostrings case camel -l | tr '\n' ' ')" = "userID httpServer " ]
+  run gostrings case title x
+  [ "$status" -eq 64 ]
+}
+
+@test "replacer" {
+  [ "$(echo 'cat chases dog' | gostrings replacer cat dog dog cat)" = "dog chases cat" ]
+
+  printf 'cat\tdog\ndog\tcat\n' > "$BATS_TMPDIR/swap.tsv"
+  [ "$(echo 'cat chases dog' | gostrings replacer --file "$BATS_TMPDIR/swap.tsv")" = "dog chases cat" ]
+
+  echo '{"cat": "dog", "dog": "cat"}' > "$BATS_TMPDIR/swap.json"
+  [ "$(echo 'cat chases dog' | gostrings replacer --file "$BATS_TMPDIR/swap.json")" = "dog chases cat" ]
+
+  run gostrings replacer cat
+  [ "$status" -eq 64 ]
+}
+
+@test "display width" {
+  [ "$(gostrings width 'Hello, 世界')" = "11" ]
+  [ "$(printf '名前\nabc\n' | gostrings pad --width 6 --align right -l | tr '\n' '|')" = "  名前|   abc|" ]
+  [ "$(echo 'こんにちは' | gostrings truncate --width 6 -l)" = "こん…" ]
+  run gostrings pad a
+  [ "$status" -eq 64 ]
+}
@@ -0,0 +1,39 @@
+// Package cmdtest runs the commands of a gosh group in-process for its tests.
+package cmdtest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aca/gosh/utils"
+	"github.com/spf13/cobra"
+)
+
+// Run runs the command lines of tests as commands of root, reporting those
+// whose output or exit status is not the one expected. The Args of tests
+// start with the name of the command.
+func Run(t *testing.T, root *cobra.Command, tests []utils.Example) {
+	t.Helper()
+	for _, tt := range tests {
+		output, exit, err := tt.Run(root, nil)
+		if output != tt.Output || exit != tt.Exit {
+			t.Errorf("%q with stdin %q: got exit %d (%v), output %q; want exit %d, output %q",
+				tt.Args, tt.Stdin, exit, err, output, tt.Exit, tt.Output)
+		}
+	}
+}
+
+// Examples runs the examples of the commands of root, as gosh selftest does,
+// and reports those failing and the commands without examples.
+func Examples(t *testing.T, root *cobra.Command) {
+	t.Helper()
+	utils.RunExamples(root, root, func(r utils.ExampleResult) {
+		switch {
+		case r.Missing:
+			t.Errorf("%s has no examples", strings.Join(r.Path, " "))
+		case !r.OK():
+			t.Errorf("%s: got exit %d (%v), output %q; want exit %d, output %q",
+				r.Example.CommandLine(strings.Join(r.Path, " ")), r.Exit, r.Err, r.Output, r.Example.Exit, r.Example.Output)
+		}
+	})
+}
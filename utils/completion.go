@@ -1,10 +1,14 @@
 package utils
 
 import (
-	"os"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strconv"
 	"strings"
 	"text/template"
 
+	"github.com/aca/gosh/registry"
 	"github.com/spf13/cobra"
 )
 
@@ -47,17 +51,19 @@ func NewCompletionCommand(name string) *cobra.Command {
 		DisableFlagsInUseLine: true,
 		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
 		Args:                  cobra.ExactValidArgs(1),
-		Run: func(cmd *cobra.Command, args []string) {
+		RunE: func(cmd *cobra.Command, args []string) error {
+			w := cmd.OutOrStdout()
 			switch args[0] {
 			case "bash":
-				cmd.Root().GenBashCompletion(os.Stdout)
+				return cmd.Root().GenBashCompletion(w)
 			case "zsh":
-				cmd.Root().GenZshCompletion(os.Stdout)
+				return cmd.Root().GenZshCompletion(w)
 			case "fish":
-				cmd.Root().GenFishCompletion(os.Stdout, true)
+				return cmd.Root().GenFishCompletion(w, true)
 			case "powershell":
-				cmd.Root().GenPowerShellCompletion(os.Stdout)
+				return cmd.Root().GenPowerShellCompletion(w)
 			}
+			return nil
 		},
 	}
 
@@ -74,3 +80,86 @@ func NewCompletionCommand(name string) *cobra.Command {
 
 	return cmd
 }
+
+// Complete returns the completions of toComplete following args on the
+// command line of root, as offered to shells by cobra's __complete command,
+// along with the completion directive.
+func Complete(root *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective, error) {
+	var out bytes.Buffer
+	args = append(append([]string{cobra.ShellCompNoDescRequestCmd}, args...), toComplete)
+	err := ExecuteArgs(root, args, strings.NewReader(""), &out, ioutil.Discard)
+
+	// cobra adds the __complete command on demand, but doesn't remove it.
+	for _, c := range root.Commands() {
+		if c.Name() == cobra.ShellCompRequestCmd {
+			root.RemoveCommand(c)
+		}
+	}
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError, err
+	}
+	return ParseCompletions(out.String())
+}
+
+// ParseCompletions parses the output of a __complete command: completions,
+// one per line, followed by the completion directive as :directive.
+func ParseCompletions(out string) ([]string, cobra.ShellCompDirective, error) {
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	last := lines[len(lines)-1]
+	if !strings.HasPrefix(last, ":") {
+		return nil, cobra.ShellCompDirectiveError, fmt.Errorf("invalid completion output %q", last)
+	}
+	directive, err := strconv.Atoi(last[1:])
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError, err
+	}
+	return lines[:len(lines)-1], cobra.ShellCompDirective(directive), nil
+}
+
+// ParamCompleter returns the completions of toComplete as an argument for
+// param.
+type ParamCompleter func(param registry.Param, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// CompleteArgs returns a ValidArgsFunction completing the arguments of f:
+// strings with complete, bools with true and false, functions with the names
+// of the unicode functions and range tables they may be, negated if
+// toComplete starts with !, and nothing for other types or past the last
+// parameter. A nil complete completes nothing.
+func CompleteArgs(f *registry.Func, complete ParamCompleter) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		i := len(args)
+		if f.Variadic && i >= len(f.Params) {
+			i = len(f.Params) - 1
+		}
+		if i >= len(f.Params) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		switch p := f.Params[i]; strings.TrimPrefix(p.Type, "...") {
+		case "string":
+			if complete != nil {
+				return complete(p, toComplete)
+			}
+		case "bool":
+			return []string{"true", "false"}, cobra.ShellCompDirectiveNoFileComp
+		case "func(rune) bool":
+			names := registry.PredicateNames()
+			if strings.HasPrefix(toComplete, "!") {
+				for i, name := range names {
+					names[i] = "!" + name
+				}
+			}
+			return names, cobra.ShellCompDirectiveNoFileComp
+		case "func(rune) rune":
+			return registry.MappingNames(), cobra.ShellCompDirectiveNoFileComp
+		}
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeValues returns a completion function offering values.
+func completeValues(values ...string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return values, cobra.ShellCompDirectiveNoFileComp
+	}
+}
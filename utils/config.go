@@ -0,0 +1,358 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aca/gosh/registry"
+	"github.com/spf13/cobra"
+)
+
+// configKeys are the flags whose default may be set in the config file.
+var configKeys = []string{"output", "lines", "null", "error-format", "go-literal"}
+
+// Config is the user configuration, read from $XDG_CONFIG_HOME/gosh/config:
+//
+//	# Defaults for every command.
+//	output = "json"  # or yaml, csv...
+//	error-format = "json"
+//
+//	# Defaults for the commands of a group.
+//	[gofilepath]
+//	lines = true
+//
+//	# Aliases, run as a pipeline by a command of the group of their first
+//	# function.
+//	[alias]
+//	basename-noext = "gofilepath base | gostrings trimsuffix .go"
+//
+// Values are Go string literals, raw strings in single quotes, or bare words,
+// and may be followed by a # comment.
+// The same configuration may be written as a JSON object, with an object per
+// section.
+type Config struct {
+	// Defaults maps group names, or "" for every command, to the default
+	// values of flags.
+	Defaults map[string]map[string]string
+
+	// Aliases maps alias names to pipeline expressions.
+	Aliases map[string]string
+}
+
+// ConfigError is returned for an invalid config file.
+type ConfigError struct {
+	Path string
+	Line int
+	Err  error
+}
+
+func (e *ConfigError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %v", e.Path, e.Line, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+func (e *ConfigError) Unwrap() error { return e.Err }
+
+var (
+	configOnce sync.Once
+	config     *Config
+	configErr  error
+)
+
+// ConfigPath returns the path of the config file.
+func ConfigPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "gosh", "config")
+}
+
+// LoadConfig reads the config file the first time it is called, and returns
+// the same result afterwards. A missing file is an empty configuration.
+func LoadConfig() (*Config, error) {
+	configOnce.Do(func() {
+		config, configErr = &Config{}, nil
+		path := ConfigPath()
+		if path == "" {
+			return
+		}
+		b, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			return
+		}
+		if err != nil {
+			configErr = &ConfigError{Path: path, Err: err}
+			return
+		}
+		config, configErr = ParseConfig(path, b)
+	})
+	return config, configErr
+}
+
+// DisableConfig makes LoadConfig return an empty configuration from now on,
+// so that commands run with their built-in defaults.
+func DisableConfig() {
+	configOnce.Do(func() {})
+	config, configErr = &Config{}, nil
+}
+
+// UseConfig makes the commands under root use the defaults of the config
+// file, which is loaded by the PersistentPreRunE of root.
+//
+// setup, if not nil, is called with the config right away, to add commands
+// defined by it such as aliases: they must exist before the command line is
+// parsed. Errors loading the config or setting it up are returned by the
+// PersistentPreRunE too, so that commands such as help still run.
+func UseConfig(root *cobra.Command, setup func(c *Config) error) {
+	var setupErr error
+	if setup != nil {
+		if c, err := LoadConfig(); err == nil {
+			setupErr = setup(c)
+		}
+	}
+
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		c, err := LoadConfig()
+		if err != nil {
+			return err
+		}
+		if setupErr != nil {
+			return setupErr
+		}
+		return c.Apply(cmd)
+	}
+}
+
+// Apply sets the flags of cmd that were not given on the command line to
+// their default in c, those of the group of cmd taking precedence.
+func (c *Config) Apply(cmd *cobra.Command) error {
+	group := ""
+	for p := cmd; p != nil; p = p.Parent() {
+		if _, ok := c.Defaults[p.Name()]; ok {
+			group = p.Name()
+			break
+		}
+	}
+
+	for _, section := range []string{"", group} {
+		for key, value := range c.Defaults[section] {
+			f := cmd.Flags().Lookup(key)
+			if f == nil || f.Changed {
+				continue
+			}
+			if err := f.Value.Set(value); err != nil {
+				return &ConfigError{Path: ConfigPath(), Err: fmt.Errorf("%s: %v", key, err)}
+			}
+		}
+	}
+	return nil
+}
+
+// AliasNames returns the names of the aliases of c, sorted.
+func (c *Config) AliasNames() []string {
+	names := make([]string, 0, len(c.Aliases))
+	for name := range c.Aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseConfig parses the config file read from path.
+func ParseConfig(path string, b []byte) (*Config, error) {
+	c := &Config{
+		Defaults: make(map[string]map[string]string),
+		Aliases:  make(map[string]string),
+	}
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) {
+		if err := c.parseJSON(b); err != nil {
+			return nil, &ConfigError{Path: path, Err: err}
+		}
+		return c, nil
+	}
+
+	section := ""
+	sc := bufio.NewScanner(bytes.NewReader(b))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "" || line[0] == '#':
+			continue
+		case line[0] == '[':
+			line = stripComment(line)
+			if !strings.HasSuffix(line, "]") {
+				return nil, &ConfigError{Path: path, Line: n, Err: fmt.Errorf("invalid section %s", line)}
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if !configSection(section) {
+				return nil, &ConfigError{Path: path, Line: n, Err: fmt.Errorf("unknown section %q", section)}
+			}
+			continue
+		}
+
+		i := strings.IndexByte(line, '=')
+		if i < 0 {
+			return nil, &ConfigError{Path: path, Line: n, Err: fmt.Errorf("expected key = value, got %s", line)}
+		}
+		key := strings.TrimSpace(line[:i])
+		value, err := configValue(stripComment(line[i+1:]))
+		if err == nil {
+			err = c.set(section, key, value)
+		}
+		if err != nil {
+			return nil, &ConfigError{Path: path, Line: n, Err: err}
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, &ConfigError{Path: path, Err: err}
+	}
+	return c, nil
+}
+
+func (c *Config) parseJSON(b []byte) error {
+	var sections map[string]json.RawMessage
+	if err := json.Unmarshal(b, &sections); err != nil {
+		return err
+	}
+	for name, raw := range sections {
+		var values map[string]interface{}
+		if !configSection(name) {
+			// A default for every command, rather than a section.
+			var v interface{}
+			if err := json.Unmarshal(raw, &v); err != nil {
+				return err
+			}
+			values, name = map[string]interface{}{name: v}, ""
+		} else if err := json.Unmarshal(raw, &values); err != nil {
+			return fmt.Errorf("section %s: %v", name, err)
+		}
+		for key, v := range values {
+			if err := c.set(name, key, fmt.Sprint(v)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// configSection reports whether name is a section of the config file, the
+// name of a group or alias.
+func configSection(name string) bool {
+	if name == "alias" {
+		return true
+	}
+	for _, g := range registry.Groups() {
+		if name == g {
+			return true
+		}
+	}
+	return false
+}
+
+// set sets key to value in section.
+func (c *Config) set(section, key, value string) error {
+	if section == "alias" {
+		if key == "" || strings.ContainsAny(key, " \t.") {
+			return fmt.Errorf("invalid alias name %q", key)
+		}
+		c.Aliases[key] = value
+		return nil
+	}
+
+	known := false
+	for _, k := range configKeys {
+		known = known || k == key
+	}
+	if !known {
+		return fmt.Errorf("unknown key %q, expected one of %s", key, strings.Join(configKeys, ", "))
+	}
+	if err := checkConfigValue(key, value); err != nil {
+		return fmt.Errorf("%s: %v", key, err)
+	}
+	if c.Defaults[section] == nil {
+		c.Defaults[section] = make(map[string]string)
+	}
+	c.Defaults[section][key] = value
+	return nil
+}
+
+// checkConfigValue checks that value is valid for key.
+func checkConfigValue(key, value string) error {
+	var valid []string
+	switch key {
+	case "output":
+		if strings.HasPrefix(value, templatePrefix) {
+			_, err := parseTemplate(strings.TrimPrefix(value, templatePrefix))
+			return err
+		}
+		valid = OutputFormats
+	case "error-format":
+		valid = []string{"text", "json"}
+	default:
+		_, err := strconv.ParseBool(value)
+		return err
+	}
+	for _, v := range valid {
+		if value == v {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid value %q, expected one of %s", value, strings.Join(valid, ", "))
+}
+
+// stripComment returns the value s of a key without surrounding blanks and
+// its trailing comment: a # after a blank, outside of the quoted string the
+// value may be.
+func stripComment(s string) string {
+	s = strings.TrimSpace(s)
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote == '"' && c == '\\':
+			i++
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case i == 0 && (c == '"' || c == '`' || c == '\''):
+			quote = c
+		case c == '#' && (i == 0 || s[i-1] == ' ' || s[i-1] == '\t'):
+			return strings.TrimSpace(s[:i])
+		}
+	}
+	return strings.TrimSpace(s)
+}
+
+// configValue returns the value of a key: a Go string literal, a raw string
+// in single quotes or a bare word.
+func configValue(s string) (string, error) {
+	switch {
+	case s == "":
+		return "", errors.New("missing value")
+	case s[0] == '"' || s[0] == '`':
+		return strconv.Unquote(s)
+	case s[0] == '\'':
+		if len(s) < 2 || s[len(s)-1] != '\'' {
+			return "", fmt.Errorf("unterminated string %s", s)
+		}
+		return s[1 : len(s)-1], nil
+	}
+	return s, nil
+}
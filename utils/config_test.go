@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	tests := []struct {
+		src  string
+		want *Config
+		err  string
+	}{
+		{
+			src: "# Defaults.\noutput = \"json\"\nerror-format = json\n\n[gofilepath]\nlines = true\n" +
+				"[alias]\nnoext = 'gofilepath base | trimsuffix .go'\nup = `toupper`\n",
+			want: &Config{
+				Defaults: map[string]map[string]string{
+					"":           {"output": "json", "error-format": "json"},
+					"gofilepath": {"lines": "true"},
+				},
+				Aliases: map[string]string{"noext": "gofilepath base | trimsuffix .go", "up": "toupper"},
+			},
+		},
+		{
+			src: "output = \"template={{.dir}}\\t{{.file}}\"\n",
+			want: &Config{
+				Defaults: map[string]map[string]string{"": {"output": "template={{.dir}}\t{{.file}}"}},
+				Aliases:  map[string]string{},
+			},
+		},
+		{
+			src: `{"output": "csv", "gostrings": {"null": true}, "alias": {"up": "toupper"}}`,
+			want: &Config{
+				Defaults: map[string]map[string]string{"": {"output": "csv"}, "gostrings": {"null": "true"}},
+				Aliases:  map[string]string{"up": "toupper"},
+			},
+		},
+		{
+			src: "output = json  # or yaml\n[alias] # aliases\n" +
+				"hash = 'trimprefix #' # comment\nq = \"split \\\" #\\\"\"\t# comment\nit = it's #1\n",
+			want: &Config{
+				Defaults: map[string]map[string]string{"": {"output": "json"}},
+				Aliases:  map[string]string{"hash": "trimprefix #", "q": `split " #"`, "it": "it's"},
+			},
+		},
+		{src: "\noutput json\n", err: "config:2: expected key = value, got output json"},
+		{src: "[gofilepath\n", err: "config:1: invalid section [gofilepath"},
+		{src: "[nosuch]\n", err: `config:1: unknown section "nosuch"`},
+		{src: "color = true\n", err: `config:1: unknown key "color", expected one of output, lines, null, error-format, go-literal`},
+		{src: "output = xml\n", err: `config:1: output: invalid value "xml", expected one of text, json, jsonl, yaml, csv, tsv, shell, go, table`},
+		{src: "lines = yes\n", err: `config:1: lines: strconv.ParseBool: parsing "yes": invalid syntax`},
+		{src: "output =\n", err: "config:1: missing value"},
+		{src: "output = # json\n", err: "config:1: missing value"},
+		{src: "lines = true# comment\n", err: `config:1: lines: strconv.ParseBool: parsing "true# comment": invalid syntax`},
+		{src: "output = 'json\n", err: "config:1: unterminated string 'json"},
+		{src: "[alias]\na.b = toupper\n", err: `config:2: invalid alias name "a.b"`},
+		{src: `{"gostrings": 1}`, err: "config: section gostrings: json: cannot unmarshal number into Go value of type map[string]interface {}"},
+	}
+	for _, tt := range tests {
+		c, err := ParseConfig("config", []byte(tt.src))
+		if tt.err != "" {
+			var configErr *ConfigError
+			if !errors.As(err, &configErr) || err.Error() != tt.err {
+				t.Errorf("ParseConfig(%q): got error %v, want %s", tt.src, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseConfig(%q): %v", tt.src, err)
+			continue
+		}
+		if !reflect.DeepEqual(c, tt.want) {
+			t.Errorf("ParseConfig(%q) = %+v, want %+v", tt.src, c, tt.want)
+		}
+	}
+}
@@ -1,5 +1,240 @@
 package utils
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/aca/gosh/registry"
+	"github.com/spf13/cobra"
+)
 
 var ErrInvalidOutputFormat = errors.New("invalid output format")
+
+// ErrFalse is returned by commands whose boolean result is false. It is not
+// reported as an error, only through the exit status.
+var ErrFalse = errors.New("false")
+
+// Exit statuses. 1 is reserved for boolean false, errors use the ranges of
+// sysexits.h so that scripts can tell them apart.
+const (
+	ExitOK      = 0
+	ExitFalse   = 1
+	ExitFailure = 2  // any other error
+	ExitUsage   = 64 // invalid command line
+	ExitParse   = 65 // invalid input, such as a malformed URL or number
+	ExitLookup  = 68 // name resolution failed
+	ExitTempErr = 75 // name resolution timed out or failed temporarily
+	ExitConfig  = 78 // invalid config file
+)
+
+// runError wraps the errors returned by the run functions of commands, to
+// tell them from usage errors detected by cobra before running a command.
+type runError struct {
+	err error
+}
+
+func (e *runError) Error() string { return e.err.Error() }
+func (e *runError) Unwrap() error { return e.err }
+
+// AddErrorFlags registers the flags controlling how errors are reported on
+// root.
+func AddErrorFlags(root *cobra.Command) {
+	root.PersistentFlags().String("error-format", "text", "error format: text or json")
+	root.RegisterFlagCompletionFunc("error-format", completeValues("text", "json"))
+}
+
+// Execute runs root and returns the exit status for its outcome. Errors are
+// printed to stderr, in the format selected by --error-format.
+func Execute(root *cobra.Command) int {
+	root.SilenceErrors = true
+	wrapRunErrors(root)
+
+	cmd, err := root, unknownCommand(root, os.Args[1:])
+	if err == nil {
+		cmd, err = root.ExecuteC()
+	}
+	if err == nil {
+		return ExitOK
+	}
+
+	format := "text"
+	if cmd != nil {
+		if f, ferr := cmd.Flags().GetString("error-format"); ferr == nil {
+			format = f
+		}
+	}
+	return ReportError(root.ErrOrStderr(), err, format)
+}
+
+// unknownCommand returns a usage error if args run a group, other than root,
+// with a subcommand it doesn't have: cobra shows the help of the group
+// instead, successfully.
+func unknownCommand(root *cobra.Command, args []string) error {
+	c, rest, err := root.Find(args)
+	if err != nil || c == root || c.Runnable() || len(rest) == 0 || strings.HasPrefix(rest[0], "-") {
+		return nil
+	}
+	return fmt.Errorf("unknown command %q for %q", rest[0], c.CommandPath())
+}
+
+// wrapped holds the commands whose errors are wrapped by wrapRunErrors.
+var wrapped = make(map[*cobra.Command]bool)
+
+// wrapRunErrors wraps the errors returned by the run functions of cmd and its
+// subcommands in runError, once.
+func wrapRunErrors(cmd *cobra.Command) {
+	if runE := cmd.RunE; runE != nil && !wrapped[cmd] {
+		wrapped[cmd] = true
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			if err := runE(cmd, args); err != nil {
+				return &runError{err}
+			}
+			return nil
+		}
+	}
+	for _, c := range cmd.Commands() {
+		wrapRunErrors(c)
+	}
+}
+
+// ReportError writes err to w in format, text or json, and returns the exit
+// status it maps to.
+func ReportError(w io.Writer, err error, format string) int {
+	code := ExitCode(err)
+	if code == ExitOK || code == ExitFalse {
+		return code
+	}
+	// Commands run as processes, like plugins, report their own errors.
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return code
+	}
+
+	if format != "json" {
+		fmt.Fprintln(w, "Error:", err)
+		return code
+	}
+
+	b, merr := marshalJSON(ErrorRecord(err))
+	if merr != nil {
+		fmt.Fprintln(w, "Error:", err)
+		return code
+	}
+	fmt.Fprintln(w, string(b))
+	return code
+}
+
+// ErrorRecord returns the message and exit status of err, along with the type
+// and fields of the Go error it wraps if any.
+func ErrorRecord(err error) Record {
+	r := Record{
+		{Name: "error", Value: err.Error()},
+		{Name: "code", Value: ExitCode(err)},
+	}
+	if typ, fields := errorFields(err); typ != "" {
+		r = append(r, Field{Name: "type", Value: typ}, Field{Name: "fields", Value: fields})
+	}
+	return r
+}
+
+// ExitCode returns the exit status for err.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	if errors.Is(err, ErrFalse) {
+		return ExitFalse
+	}
+
+	var (
+		re        *runError
+		arityErr  *registry.ArityError
+		configErr *ConfigError
+		exitErr   *exec.ExitError
+	)
+	if errors.As(err, &configErr) {
+		return ExitConfig
+	}
+	// Processes, like plugins, exit with their own status.
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+		return exitErr.ExitCode()
+	}
+	if !errors.As(err, &re) || errors.Is(err, ErrInvalidOutputFormat) || errors.Is(err, ErrUnknownFunc) || errors.As(err, &arityErr) {
+		return ExitUsage
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		if dnsErr.IsTimeout || dnsErr.IsTemporary {
+			return ExitTempErr
+		}
+		return ExitLookup
+	}
+
+	var (
+		numErr   *strconv.NumError
+		argErr   *registry.ArgError
+		parseErr *net.ParseError
+		addrErr  *net.AddrError
+		urlErr   *url.Error
+		escErr   url.EscapeError
+		hostErr  url.InvalidHostError
+		jsonErr  *json.SyntaxError
+		typeErr  *json.UnmarshalTypeError
+	)
+	switch {
+	case errors.As(err, &numErr), errors.As(err, &argErr),
+		errors.As(err, &parseErr), errors.As(err, &addrErr),
+		errors.As(err, &urlErr), errors.As(err, &escErr), errors.As(err, &hostErr),
+		errors.As(err, &jsonErr), errors.As(err, &typeErr),
+		errors.Is(err, filepath.ErrBadPattern):
+		return ExitParse
+	}
+	return ExitFailure
+}
+
+// errorFields returns the type and the exported fields of the outermost
+// struct error wrapped by err, such as the Name and IsNotFound fields of a
+// *net.DNSError. Fields holding errors are reported as their message.
+func errorFields(err error) (string, Record) {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if _, ok := e.(*runError); ok {
+			continue
+		}
+
+		rv := reflect.ValueOf(e)
+		for rv.Kind() == reflect.Ptr {
+			rv = rv.Elem()
+		}
+		if rv.Kind() != reflect.Struct {
+			continue
+		}
+
+		fields := Record{}
+		t := rv.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath != "" {
+				continue
+			}
+			v := rv.Field(i).Interface()
+			if ferr, ok := v.(error); ok {
+				v = ferr.Error()
+			}
+			fields = append(fields, Field{Name: t.Field(i).Name, Value: normalize(v)})
+		}
+		if len(fields) > 0 {
+			return fmt.Sprintf("%T", e), fields
+		}
+	}
+	return "", nil
+}
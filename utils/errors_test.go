@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/aca/gosh/registry"
+)
+
+func TestExitCode(t *testing.T) {
+	_, numErr := strconv.Atoi("x")
+	_, urlErr := url.Parse(":")
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{nil, ExitOK},
+		{ErrFalse, ExitFalse},
+		{&runError{ErrFalse}, ExitFalse},
+		// Errors of cobra, before any run function.
+		{errors.New(`unknown flag: --x`), ExitUsage},
+		{numErr, ExitUsage},
+		{&runError{ErrUnknownFunc}, ExitUsage},
+		{&runError{ErrInvalidOutputFormat}, ExitUsage},
+		{&runError{&registry.ArityError{Func: &registry.Func{Name: "f"}}}, ExitUsage},
+		{&runError{numErr}, ExitParse},
+		{&runError{fmt.Errorf("toupper: %w", numErr)}, ExitParse},
+		{&runError{&registry.ArgError{Type: "rune", Arg: "ab"}}, ExitParse},
+		{&runError{urlErr}, ExitParse},
+		{&runError{&net.ParseError{Type: "IP address", Text: "x"}}, ExitParse},
+		{&runError{&net.DNSError{Err: "no such host", IsNotFound: true}}, ExitLookup},
+		{&runError{&net.DNSError{Err: "timeout", IsTimeout: true}}, ExitTempErr},
+		{&runError{&net.DNSError{Err: "server misbehaving", IsTemporary: true}}, ExitTempErr},
+		{&ConfigError{Path: "config", Err: errors.New("x")}, ExitConfig},
+		{&runError{errors.New("x")}, ExitFailure},
+	}
+	for _, tt := range tests {
+		if got := ExitCode(tt.err); got != tt.want {
+			t.Errorf("ExitCode(%#v) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
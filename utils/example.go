@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Example is an executable example of a command: running it with Args, and
+// Stdin as its input, writes Output to stdout and exits with status Exit.
+type Example struct {
+	Args   []string
+	Stdin  string
+	Output string
+	Exit   int
+}
+
+// examples maps commands to the examples set by SetExamples.
+var examples = make(map[*cobra.Command][]Example)
+
+// SetExamples sets the examples of cmd, which are shown in its help and run
+// by gosh selftest. The help shows the command as it is named when
+// SetExamples is called, so cmd should be added to its group first.
+func SetExamples(cmd *cobra.Command, exs ...Example) {
+	examples[cmd] = exs
+
+	lines := make([]string, 0, len(exs))
+	for _, ex := range exs {
+		lines = append(lines, ex.String(cmd.CommandPath()))
+	}
+	cmd.Example = strings.Join(lines, "\n\n")
+}
+
+// ExamplesOf returns the examples of cmd.
+func ExamplesOf(cmd *cobra.Command) []Example {
+	return examples[cmd]
+}
+
+// Run runs ex in-process as the command of root at path, the command line
+// running it, and returns its output and exit status.
+func (ex Example) Run(root *cobra.Command, path []string) (output string, exit int, err error) {
+	var out bytes.Buffer
+	args := append(append([]string{}, path...), ex.Args...)
+	err = ExecuteArgs(root, args, strings.NewReader(ex.Stdin), &out, ioutil.Discard)
+	return out.String(), ExitCode(err), err
+}
+
+// CommandLine returns the shell command line running ex as command.
+func (ex Example) CommandLine(command string) string {
+	var b strings.Builder
+	if ex.Stdin != "" {
+		fmt.Fprintf(&b, "printf %%s %s | ", ShellQuote(ex.Stdin))
+	}
+	b.WriteString(command)
+	for _, arg := range ex.Args {
+		b.WriteString(" " + ShellQuote(arg))
+	}
+	return b.String()
+}
+
+// String returns ex as a shell session running command, indented for help.
+func (ex Example) String(command string) string {
+	s := "  $ " + ex.CommandLine(command)
+	if out := strings.TrimSuffix(ex.Output, "\n"); out != "" {
+		s += "\n  " + strings.Replace(out, "\n", "\n  ", -1)
+	}
+	if ex.Exit != ExitOK {
+		s += fmt.Sprintf("\n  (exit status %d)", ex.Exit)
+	}
+	return s
+}
+
+// ExampleResult is the outcome of an example run by RunExamples.
+type ExampleResult struct {
+	// Path is the command line running the command of the example, below
+	// the root it is run from.
+	Path    []string
+	Example Example
+
+	// Missing is set, and Example is zero, for a command running a function
+	// but without examples.
+	Missing bool
+
+	Output string
+	Exit   int
+	Err    error
+}
+
+// OK reports whether the example passed: its output and exit status are the
+// ones expected.
+func (r ExampleResult) OK() bool {
+	return !r.Missing && r.Output == r.Example.Output && r.Exit == r.Example.Exit
+}
+
+// RunExamples runs the examples of cmd and its subcommands in-process, as
+// commands of root, and calls report with the result of each one. Commands
+// running a function without examples are reported as Missing.
+func RunExamples(root, cmd *cobra.Command, report func(ExampleResult)) {
+	path := strings.Fields(cmd.CommandPath())[len(strings.Fields(root.CommandPath())):]
+
+	exs := ExamplesOf(cmd)
+	if len(exs) == 0 && FuncOf(cmd) != nil {
+		report(ExampleResult{Path: path, Missing: true})
+	}
+	for _, ex := range exs {
+		out, exit, err := ex.Run(root, path)
+		report(ExampleResult{Path: path, Example: ex, Output: out, Exit: exit, Err: err})
+	}
+
+	for _, c := range cmd.Commands() {
+		RunExamples(root, c, report)
+	}
+}
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// ExecuteArgs runs root in-process with args as its command line, reading
+// stdin from in and writing output and errors to out and errOut. It can be
+// called any number of times on the same tree: flags are reset to their
+// defaults before each run. The error is not printed, ExitCode maps it to the
+// exit status Execute would return.
+func ExecuteArgs(root *cobra.Command, args []string, in io.Reader, out, errOut io.Writer) error {
+	resetFlags(root)
+	wrapRunErrors(root)
+
+	root.SetArgs(args)
+	root.SetIn(in)
+	root.SetOut(out)
+	root.SetErr(errOut)
+	root.SilenceErrors = true
+	if err := unknownCommand(root, args); err != nil {
+		return err
+	}
+	_, err := root.ExecuteC()
+	return err
+}
+
+// resetFlags sets back the flags of cmd and its subcommands to their default
+// values, as if they were never parsed.
+func resetFlags(cmd *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if f.Changed {
+			f.Value.Set(f.DefValue)
+			f.Changed = false
+		}
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+
+	for _, c := range cmd.Commands() {
+		resetFlags(c)
+	}
+}
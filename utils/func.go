@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"github.com/aca/gosh/registry"
+	"github.com/spf13/cobra"
+)
+
+// funcs maps the commands created by NewFuncCommand to their Func.
+var funcs = make(map[*cobra.Command]*registry.Func)
+
+// FuncOf returns the function run by cmd, or nil if cmd doesn't run one.
+func FuncOf(cmd *cobra.Command) *registry.Func {
+	return funcs[cmd]
+}
+
+// NewFuncCommand returns the command running f.
+//
+// Its arguments are the parameters of f; the first one may be read from
+// stdin if it is a string. Bool results are reported as the exit status,
+// functions with several results print a Record of them.
+func NewFuncCommand(f *registry.Func) *cobra.Command {
+	nargs := len(f.Params)
+	cmd := &cobra.Command{
+		Use:                   f.Name,
+		Short:                 f.Signature,
+		Long:                  f.Signature + "\n\n" + f.Doc,
+		Args:                  cobra.ExactArgs(nargs),
+		DisableFlagsInUseLine: true,
+		RunE:                  FuncRunE(f),
+	}
+
+	switch {
+	case f.Variadic:
+		cmd.Args = cobra.MinimumNArgs(nargs - 1)
+	case f.Stdin():
+		cmd.Args = cobra.RangeArgs(nargs-1, nargs)
+	}
+	funcs[cmd] = f
+	return cmd
+}
+
+// FuncRunE returns the RunE of the command running f, as made by
+// NewFuncCommand.
+func FuncRunE(f *registry.Func) func(*cobra.Command, []string) error {
+	if f.Variadic {
+		return func(cmd *cobra.Command, args []string) error {
+			return Run(len(args), func(cmd *cobra.Command, args []string, p *Printer) error {
+				return RunFunc(f, args, p)
+			})(cmd, args)
+		}
+	}
+	return Run(len(f.Params), func(cmd *cobra.Command, args []string, p *Printer) error {
+		return RunFunc(f, args, p)
+	})
+}
+
+// RunFunc calls f with args and prints its results with p.
+func RunFunc(f *registry.Func, args []string, p *Printer) error {
+	results, err := f.Invoke(args)
+	if err != nil {
+		return err
+	}
+
+	switch f.Shape() {
+	case registry.ShapeNone:
+		return nil
+	case registry.ShapeBool:
+		return p.Bool(results[0].(bool))
+	case registry.ShapeValue, registry.ShapeList:
+		return p.Print(results[0])
+	}
+
+	r := make(Record, len(results))
+	for i, v := range results {
+		r[i] = Field{Name: f.Results[i].Name, Value: v}
+	}
+	return p.Print(r)
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aca/gosh/registry"
+	"github.com/spf13/cobra"
+)
+
+// decodeArgs decodes the arguments of cmd in Go literal mode, and returns
+// them as is otherwise.
+func decodeArgs(cmd *cobra.Command, args []string) ([]string, error) {
+	literal, err := cmd.Flags().GetBool("go-literal")
+	if err != nil || !literal {
+		return args, err
+	}
+
+	decoded := make([]string, len(args))
+	for i, arg := range args {
+		if decoded[i], err = DecodeArg(arg); err != nil {
+			return nil, err
+		}
+	}
+	return decoded, nil
+}
+
+// DecodeArg decodes an argument given in Go literal mode. @file is replaced
+// by the content of file, read verbatim, and @@ at the start of an argument
+// stands for a single @. Any other argument is decoded as the contents of a
+// double-quoted Go string literal, so that \t is a tab, \x00 a NUL byte and
+// é an é; double quotes need not be escaped.
+func DecodeArg(arg string) (string, error) {
+	switch {
+	case strings.HasPrefix(arg, "@@"):
+		arg = arg[1:]
+	case strings.HasPrefix(arg, "@"):
+		b, err := ioutil.ReadFile(arg[1:])
+		return string(b), err
+	}
+
+	var b strings.Builder
+	for s := arg; s != ""; {
+		// Anything but escapes is kept as is, including invalid UTF-8.
+		if s[0] != '\\' {
+			_, n := utf8.DecodeRuneInString(s)
+			b.WriteString(s[:n])
+			s = s[n:]
+			continue
+		}
+		r, multibyte, tail, err := strconv.UnquoteChar(s, '"')
+		if err != nil {
+			return "", &registry.ArgError{Type: "Go string literal", Arg: arg}
+		}
+		if r < 0x80 || multibyte {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte(byte(r))
+		}
+		s = tail
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/aca/gosh/registry"
+)
+
+func TestDecodeArg(t *testing.T) {
+	f, err := ioutil.TempFile("", "gosh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString("a\\tb\n"); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	tests := []struct{ arg, want string }{
+		{"", ""},
+		{"a\\tb", "a\tb"},
+		{"a\\x00b", "a\x00b"},
+		{"\\xff", "\xff"},
+		{"\\u00e9\\n", "é\n"},
+		{"\"quoted\"", "\"quoted\""},
+		{"é\xff", "é\xff"},
+		{"@@x\\t", "@x\t"},
+		{"a@b", "a@b"},
+		{"@" + f.Name(), "a\\tb\n"},
+	}
+	for _, tt := range tests {
+		got, err := DecodeArg(tt.arg)
+		if err != nil {
+			t.Errorf("DecodeArg(%q): %v", tt.arg, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("DecodeArg(%q) = %q, want %q", tt.arg, got, tt.want)
+		}
+	}
+
+	for _, arg := range []string{"\\q", "a\\", "\\x0"} {
+		var argErr *registry.ArgError
+		if _, err := DecodeArg(arg); !errors.As(err, &argErr) {
+			t.Errorf("DecodeArg(%q): got error %v, want an ArgError", arg, err)
+		}
+	}
+	if _, err := DecodeArg("@" + f.Name() + ".missing"); !os.IsNotExist(err) {
+		t.Errorf("DecodeArg of a missing file: got error %v", err)
+	}
+}
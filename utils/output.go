@@ -0,0 +1,448 @@
+package utils
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+// OutputFormats lists the formats accepted by the --output flag. The default,
+// text, prints scalars as is and each element of a multi-value result on its
+// own line. Besides these, template=TEXT renders each result with the
+// text/template TEXT.
+var OutputFormats = []string{"text", "json", "jsonl", "yaml", "csv", "tsv", "shell", "go", "table"}
+
+// Field is a named value of a Record.
+type Field struct {
+	Name  string
+	Value interface{}
+}
+
+// Record is a result made of several named values, such as the results of a
+// function with multiple return values. Fields keep their order in every
+// output format.
+type Record []Field
+
+// MarshalJSON encodes r as a JSON object.
+func (r Record) MarshalJSON() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, f := range r {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := marshalJSON(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		v, err := marshalJSON(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Printer writes the results of a command in the selected output format.
+type Printer struct {
+	w      io.Writer
+	format string
+
+	// term terminates each element of a result: newline, or NUL in NUL mode.
+	term string
+
+	// records is set when the input is processed record by record, in which
+	// case every result is terminated so that each input record yields its own
+	// output.
+	records bool
+
+	// header is set once the header row of a csv, tsv or table output has
+	// been written.
+	header bool
+	csv    *csv.Writer
+	table  *tabwriter.Writer
+
+	// tmpl renders results in template format.
+	tmpl *template.Template
+}
+
+// NewPrinter returns a Printer writing to w in format.
+func NewPrinter(w io.Writer, format string) (*Printer, error) {
+	p := &Printer{w: w, format: format, term: "\n"}
+	if strings.HasPrefix(format, templatePrefix) {
+		tmpl, err := parseTemplate(strings.TrimPrefix(format, templatePrefix))
+		if err != nil {
+			return nil, err
+		}
+		p.format, p.tmpl = "template", tmpl
+		return p, nil
+	}
+	switch format {
+	case "", "text":
+		p.format = "text"
+	case "json", "jsonl", "yaml", "shell", "go":
+	case "csv", "tsv":
+		p.csv = csv.NewWriter(w)
+		if format == "tsv" {
+			p.csv.Comma = '\t'
+		}
+	case "table":
+		p.table = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	default:
+		return nil, ErrInvalidOutputFormat
+	}
+	return p, nil
+}
+
+// Print writes a single result: a scalar, a slice, a struct or a Record.
+func (p *Printer) Print(v interface{}) error {
+	switch p.format {
+	case "text":
+		return p.printText(v)
+	case "json":
+		return p.printJSON(v)
+	case "jsonl":
+		if l, ok := normalize(v).([]interface{}); ok {
+			for _, e := range l {
+				if err := p.printJSON(e); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return p.printJSON(v)
+	case "yaml":
+		if p.records {
+			if _, err := io.WriteString(p.w, "---\n"); err != nil {
+				return err
+			}
+		}
+		_, err := io.WriteString(p.w, yamlDocument(normalize(v)))
+		return err
+	case "csv", "tsv", "table":
+		return p.printRow(v)
+	case "shell":
+		_, err := io.WriteString(p.w, shellWords(normalize(v))+p.term)
+		return err
+	case "go":
+		if r, ok := v.(Record); ok {
+			for _, f := range r {
+				if _, err := fmt.Fprintf(p.w, "%#v%s", f.Value, p.term); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		_, err := fmt.Fprintf(p.w, "%#v%s", v, p.term)
+		return err
+	case "template":
+		// Templates are executed with the results as returned, rather than
+		// normalized, and every rendering is terminated.
+		if err := p.tmpl.Execute(p.w, templateData(v)); err != nil {
+			return err
+		}
+		_, err := io.WriteString(p.w, p.term)
+		return err
+	}
+	return ErrInvalidOutputFormat
+}
+
+// Stream returns the writer of p, and whether results may be written to it
+// directly, as they are produced: in text format outside of line mode, where a
+// string prints as is.
+func (p *Printer) Stream() (io.Writer, bool) {
+	return p.w, p.format == "text" && !p.records
+}
+
+// Bool reports a boolean result. Outside of line mode it is reported through
+// the exit status, as a single exit status can't hold one answer per line.
+func (p *Printer) Bool(b bool) error {
+	if p.records {
+		return p.Print(b)
+	}
+	if !b {
+		return ErrFalse
+	}
+	return nil
+}
+
+// Flush writes any buffered output. Table output is aligned across all the
+// results of a command, so nothing of it is written before Flush. csv and tsv
+// rows are buffered outside of line mode only.
+func (p *Printer) Flush() error {
+	if p.csv != nil {
+		p.csv.Flush()
+		return p.csv.Error()
+	}
+	if p.table != nil {
+		return p.table.Flush()
+	}
+	return nil
+}
+
+func (p *Printer) printText(v interface{}) error {
+	var elems []string
+	switch v := v.(type) {
+	case Record:
+		for _, f := range v {
+			elems = append(elems, text(f.Value))
+		}
+	case []string:
+		elems = v
+	default:
+		n := normalize(v)
+		if l, ok := n.([]interface{}); ok {
+			for _, e := range l {
+				elems = append(elems, text(e))
+			}
+			break
+		}
+		if _, ok := n.(Record); ok {
+			return p.printJSON(v)
+		}
+		if _, err := io.WriteString(p.w, text(v)); err != nil {
+			return err
+		}
+		if p.records {
+			_, err := io.WriteString(p.w, p.term)
+			return err
+		}
+		return nil
+	}
+
+	for _, e := range elems {
+		if _, err := io.WriteString(p.w, e+p.term); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Printer) printJSON(v interface{}) error {
+	b, err := marshalJSON(normalize(v))
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(p.w, string(b)+p.term)
+	return err
+}
+
+func (p *Printer) printRow(v interface{}) error {
+	var header, row []string
+	switch n := v.(type) {
+	case Record:
+		// Fields are written as in text output, with their String method if
+		// any, rather than normalized.
+		for _, f := range n {
+			header = append(header, f.Name)
+			row = append(row, text(f.Value))
+		}
+	default:
+		switch n := normalize(v).(type) {
+		case Record:
+			for _, f := range n {
+				header = append(header, f.Name)
+				row = append(row, text(f.Value))
+			}
+		case []interface{}:
+			for _, e := range n {
+				row = append(row, text(e))
+			}
+		default:
+			row = []string{text(n)}
+		}
+	}
+
+	if header != nil && !p.header {
+		p.header = true
+		if err := p.writeRow(header); err != nil {
+			return err
+		}
+	}
+	return p.writeRow(row)
+}
+
+func (p *Printer) writeRow(row []string) error {
+	if p.table != nil {
+		_, err := io.WriteString(p.table, strings.Join(row, "\t")+"\n")
+		return err
+	}
+	if err := p.csv.Write(row); err != nil {
+		return err
+	}
+	// In line mode, each record is written as it is processed, as in the
+	// other formats.
+	if p.records {
+		p.csv.Flush()
+		return p.csv.Error()
+	}
+	return nil
+}
+
+// text returns the plain text form of a value: scalars and values with a
+// String method as they print, anything else as JSON.
+func text(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	}
+	switch n := normalize(v).(type) {
+	case Record, []interface{}:
+		b, err := marshalJSON(n)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	default:
+		return fmt.Sprint(n)
+	}
+}
+
+func marshalJSON(v interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// normalize converts v to a tree of scalars, []interface{} and Records, which
+// all the structured output formats are written from.
+//
+// Values marshaling to text and non-struct values with a String method, like
+// net.IP, become strings. Structs become Records of their exported fields, or
+// strings if they have none but a String method. Maps become Records sorted by
+// key.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil, string, bool:
+		return v
+	case Record:
+		r := make(Record, len(v))
+		for i, f := range v {
+			r[i] = Field{Name: f.Name, Value: normalize(f.Value)}
+		}
+		return r
+	case encoding.TextMarshaler:
+		if b, err := v.MarshalText(); err == nil {
+			return string(b)
+		}
+	}
+
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	s, isStringer := v.(fmt.Stringer)
+	if isStringer && rv.Kind() != reflect.Struct {
+		return s.String()
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		return rv.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return rv.Uint()
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.String:
+		return rv.String()
+	case reflect.Slice, reflect.Array:
+		l := make([]interface{}, rv.Len())
+		for i := range l {
+			l[i] = normalize(rv.Index(i).Interface())
+		}
+		return l
+	case reflect.Map:
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+		r := make(Record, len(keys))
+		for i, k := range keys {
+			r[i] = Field{Name: fmt.Sprint(k.Interface()), Value: normalize(rv.MapIndex(k).Interface())}
+		}
+		return r
+	case reflect.Struct:
+		var r Record
+		t := rv.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath != "" {
+				continue
+			}
+			r = append(r, Field{Name: t.Field(i).Name, Value: normalize(rv.Field(i).Interface())})
+		}
+		if r == nil && isStringer {
+			return s.String()
+		}
+		if r == nil {
+			r = Record{}
+		}
+		return r
+	}
+	return fmt.Sprint(v)
+}
+
+// shellWords returns a normalized value in a form that can be evaluated by a
+// POSIX shell: scalars and lists as quoted words, Records as one assignment
+// per field.
+func shellWords(n interface{}) string {
+	switch v := n.(type) {
+	case []interface{}:
+		words := make([]string, len(v))
+		for i, e := range v {
+			words[i] = ShellQuote(text(e))
+		}
+		return strings.Join(words, " ")
+	case Record:
+		lines := make([]string, len(v))
+		for i, f := range v {
+			lines[i] = shellName(f.Name) + "=" + ShellQuote(text(f.Value))
+		}
+		return strings.Join(lines, "\n")
+	}
+	return ShellQuote(text(n))
+}
+
+// ShellQuote quotes s as a single shell word.
+func ShellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_./:=@%+,-") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// shellName turns name into a valid shell variable name.
+func shellName(name string) string {
+	b := []byte(name)
+	for i, c := range b {
+		if !(c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || i > 0 && '0' <= c && c <= '9') {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"bytes"
+	"net"
+	"testing"
+)
+
+func TestPrinter(t *testing.T) {
+	record := Record{{Name: "dir", Value: "a b/"}, {Name: "file", Value: "it's"}}
+	tests := []struct {
+		format  string
+		records bool // line mode
+		values  []interface{}
+		want    string
+	}{
+		{format: "text", values: []interface{}{"a"}, want: "a"},
+		{format: "text", records: true, values: []interface{}{"a", "b"}, want: "a\nb\n"},
+		{format: "text", values: []interface{}{[]string{"a", "b"}}, want: "a\nb\n"},
+		{format: "text", values: []interface{}{record}, want: "a b/\nit's\n"},
+		{format: "text", values: []interface{}{net.ParseIP("::1")}, want: "::1"},
+		{format: "json", values: []interface{}{record}, want: `{"dir":"a b/","file":"it's"}` + "\n"},
+		{format: "json", values: []interface{}{[]int{1, 2}}, want: "[1,2]\n"},
+		{format: "json", values: []interface{}{"<&>"}, want: `"<&>"` + "\n"},
+		{format: "jsonl", values: []interface{}{[]string{"a", "b"}}, want: "\"a\"\n\"b\"\n"},
+		{format: "yaml", values: []interface{}{record}, want: "dir: a b/\nfile: it's\n"},
+		{format: "yaml", values: []interface{}{[]string{"a", "true"}}, want: "- a\n- \"true\"\n"},
+		{format: "yaml", records: true, values: []interface{}{"a", "b"}, want: "---\na\n---\nb\n"},
+		{format: "csv", values: []interface{}{record, record}, want: "dir,file\na b/,it's\na b/,it's\n"},
+		{format: "csv", values: []interface{}{[]string{"a,b", "c"}}, want: "\"a,b\",c\n"},
+		{format: "tsv", values: []interface{}{record}, want: "dir\tfile\na b/\tit's\n"},
+		{format: "shell", values: []interface{}{record}, want: "dir='a b/'\nfile='it'\\''s'\n"},
+		{format: "shell", values: []interface{}{[]string{"a", "b c"}}, want: "a 'b c'\n"},
+		{format: "go", values: []interface{}{[]string{"a"}}, want: "[]string{\"a\"}\n"},
+		{format: "go", values: []interface{}{record}, want: "\"a b/\"\n\"it's\"\n"},
+		{format: "table", values: []interface{}{record, Record{{Name: "dir", Value: "c"}, {Name: "file", Value: "d"}}},
+			want: "dir   file\na b/  it's\nc     d\n"},
+		{format: "template={{.dir}}", values: []interface{}{record}, want: "a b/\n"},
+		{format: `template={{. | upper}} {{join "," .}}`, values: []interface{}{"a"}, want: "A a\n"},
+		{format: `template={{join "," .}}`, values: []interface{}{[]string{"a", "b"}}, want: "a,b\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		p, err := NewPrinter(&buf, tt.format)
+		if err != nil {
+			t.Fatalf("NewPrinter(%q): %v", tt.format, err)
+		}
+		p.records = tt.records
+		for _, v := range tt.values {
+			if err := p.Print(v); err != nil {
+				t.Fatalf("%s: Print(%#v): %v", tt.format, v, err)
+			}
+		}
+		if err := p.Flush(); err != nil {
+			t.Fatalf("%s: Flush: %v", tt.format, err)
+		}
+		if got := buf.String(); got != tt.want {
+			t.Errorf("%s of %#v: got %q, want %q", tt.format, tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestNewPrinterInvalid(t *testing.T) {
+	for _, format := range []string{"xml", "template={{"} {
+		if _, err := NewPrinter(&bytes.Buffer{}, format); err == nil {
+			t.Errorf("NewPrinter(%q) succeeded", format)
+		}
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := []struct{ s, want string }{
+		{"", "''"},
+		{"a/b.go", "a/b.go"},
+		{"a b", "'a b'"},
+		{"it's", `'it'\''s'`},
+		{"$x", "'$x'"},
+	}
+	for _, tt := range tests {
+		if got := ShellQuote(tt.s); got != tt.want {
+			t.Errorf("ShellQuote(%q) = %s, want %s", tt.s, got, tt.want)
+		}
+	}
+}
+
+// In line mode, csv and tsv rows are written as they are printed rather than
+// on Flush, so that the output of each input line is available right away.
+func TestPrinterLineModeFlush(t *testing.T) {
+	for _, format := range []string{"csv", "tsv"} {
+		var buf bytes.Buffer
+		p, err := NewPrinter(&buf, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		p.records = true
+		if err := p.Print(Record{{Name: "a", Value: "1"}}); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := buf.String(), "a\n1\n"; got != want {
+			t.Errorf("%s: got %q before Flush, want %q", format, got, want)
+		}
+	}
+}
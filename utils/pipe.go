@@ -0,0 +1,269 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/aca/gosh/registry"
+)
+
+// Pipeline is a chain of functions called one after the other in-process,
+// parsed from an expression such as
+//
+//	trimspace | split "," | toupper
+//
+// Each stage names a function followed by its arguments, without the first
+// one: it is called once per value output by the previous stage. Slice and
+// multiple results are passed on element-wise, bool results keep the values
+// they are true for. Variadic functions, like gofilepath join, are called
+// once with all the values instead, unless their first argument may be read
+// from stdin, like gostrings replacer.
+type Pipeline struct {
+	stages []*stage
+}
+
+type stage struct {
+	f    *registry.Func
+	args []string
+}
+
+// ErrUnknownFunc is returned by ParsePipeline for names that are not functions
+// of its groups.
+var ErrUnknownFunc = errors.New("unknown function")
+
+// ParsePipeline parses expr, looking up functions in the registry among
+// groups. The function of the first stage is looked up in groups, in order,
+// and those of the next stages in the group of the previous stage: a name may
+// be qualified by its group to use another one, as in gofilepath.split or
+// gofilepath split.
+//
+// Arguments are bare words, Go string literals in double quotes or back
+// quotes, or raw strings in single quotes.
+func ParsePipeline(expr string, groups ...string) (*Pipeline, error) {
+	words, err := splitWords(expr, true, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	pl := &Pipeline{}
+	current := ""
+	for _, w := range words {
+		if len(w) == 0 {
+			return nil, errors.New("empty pipeline stage")
+		}
+
+		name, args := w[0], w[1:]
+		if len(w) > 1 && isGroup(w[0], groups) {
+			name, args = w[0]+"."+w[1], w[2:]
+		}
+		f, err := lookupFunc(name, groups, current)
+		if err != nil {
+			return nil, err
+		}
+		current = f.Group
+
+		s := &stage{f: f, args: args}
+		switch {
+		case f.Stdin() && f.Variadic:
+			if len(s.args) < len(f.Params)-2 {
+				return nil, fmt.Errorf("%s: %s takes at least %d argument(s) in a pipeline, got %d",
+					name, f.Signature, len(f.Params)-2, len(s.args))
+			}
+		case f.Stdin():
+			if len(s.args) != len(f.Params)-1 {
+				return nil, fmt.Errorf("%s: %s takes %d argument(s) in a pipeline, got %d",
+					name, f.Signature, len(f.Params)-1, len(s.args))
+			}
+		case !f.Variadic:
+			return nil, fmt.Errorf("%s can't be used in a pipeline", f.Signature)
+		case len(s.args) < len(f.Params)-1:
+			// The values are passed for the variadic parameter only.
+			return nil, fmt.Errorf("%s: %s takes at least %d argument(s) in a pipeline, got %d",
+				name, f.Signature, len(f.Params)-1, len(s.args))
+		}
+		pl.stages = append(pl.stages, s)
+	}
+	return pl, nil
+}
+
+func isGroup(name string, groups []string) bool {
+	for _, g := range groups {
+		if g == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Group returns the group of the first function of the pipeline.
+func (pl *Pipeline) Group() string {
+	return pl.stages[0].f.Group
+}
+
+// lookupFunc returns the function named name in groups. An unqualified name
+// is only looked up in group current, if set: it is an error if it is a
+// function of another group, rather than a silent change of group.
+func lookupFunc(name string, groups []string, current string) (*registry.Func, error) {
+	group := ""
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		group, name = name[:i], name[i+1:]
+	}
+	if group == "" && current != "" {
+		if f := registry.Lookup(current, name); f != nil {
+			return f, nil
+		}
+		for _, g := range groups {
+			if registry.Lookup(g, name) != nil {
+				return nil, fmt.Errorf("%w %q in %s, write %s.%s to use the one of %s", ErrUnknownFunc, name, current, g, name, g)
+			}
+		}
+	}
+
+	for _, g := range groups {
+		if group != "" && g != group {
+			continue
+		}
+		if f := registry.Lookup(g, name); f != nil {
+			return f, nil
+		}
+	}
+
+	if group != "" {
+		name = group + "." + name
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownFunc, name)
+}
+
+// Run passes values through the stages of the pipeline and returns the
+// values output by the last one.
+func (pl *Pipeline) Run(values []string) ([]string, error) {
+	for _, s := range pl.stages {
+		var err error
+		if values, err = s.run(values); err != nil {
+			return nil, fmt.Errorf("%s: %w", s.f.Name, err)
+		}
+	}
+	return values, nil
+}
+
+func (s *stage) run(values []string) ([]string, error) {
+	if !s.f.Stdin() {
+		results, err := s.f.Invoke(append(append([]string{}, s.args...), values...))
+		if err != nil {
+			return nil, err
+		}
+		return flatten(nil, results), nil
+	}
+
+	var out []string
+	for _, v := range values {
+		results, err := s.f.Invoke(append([]string{v}, s.args...))
+		if err != nil {
+			return nil, err
+		}
+		if len(results) == 1 {
+			if b, ok := results[0].(bool); ok {
+				if b {
+					out = append(out, v)
+				}
+				continue
+			}
+		}
+		out = flatten(out, results)
+	}
+	return out, nil
+}
+
+// flatten appends the text of the elements of results to values.
+func flatten(values []string, results []interface{}) []string {
+	for _, r := range results {
+		if l, ok := normalize(r).([]interface{}); ok {
+			for _, e := range l {
+				values = append(values, text(e))
+			}
+			continue
+		}
+		values = append(values, text(r))
+	}
+	return values
+}
+
+// SplitWords splits a command line into words, quoted as in a pipeline
+// expression. expand, if not nil, is applied to bare words, so that variables
+// are not expanded inside quotes.
+func SplitWords(line string, expand func(string) string) ([]string, error) {
+	words, err := splitWords(line, false, expand)
+	if err != nil {
+		return nil, err
+	}
+	return words[0], nil
+}
+
+// splitWords splits expr into words, and into stages on | if pipes is set.
+func splitWords(expr string, pipes bool, expand func(string) string) ([][]string, error) {
+	stages := [][]string{nil}
+	s := expr
+	for {
+		s = strings.TrimLeftFunc(s, unicode.IsSpace)
+		if s == "" {
+			return stages, nil
+		}
+
+		switch s[0] {
+		case '|':
+			if !pipes {
+				break
+			}
+			stages = append(stages, nil)
+			s = s[1:]
+			continue
+		case '"', '`':
+			q := quotedPrefix(s)
+			w, err := strconv.Unquote(q)
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted string %s", q)
+			}
+			stages[len(stages)-1] = append(stages[len(stages)-1], w)
+			s = s[len(q):]
+			continue
+		case '\'':
+			end := strings.IndexByte(s[1:], '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated quoted string in %q", s)
+			}
+			stages[len(stages)-1] = append(stages[len(stages)-1], s[1:end+1])
+			s = s[end+2:]
+			continue
+		}
+
+		end := strings.IndexFunc(s, func(r rune) bool {
+			return unicode.IsSpace(r) || pipes && r == '|' || r == '"' || r == '\'' || r == '`'
+		})
+		if end < 0 {
+			end = len(s)
+		}
+		w := s[:end]
+		if expand != nil {
+			w = expand(w)
+		}
+		stages[len(stages)-1] = append(stages[len(stages)-1], w)
+		s = s[end:]
+	}
+}
+
+// quotedPrefix returns the Go string literal s starts with, up to its closing
+// quote or the end of s.
+func quotedPrefix(s string) string {
+	for i := 1; i < len(s); i++ {
+		switch {
+		case s[i] == s[0]:
+			return s[:i+1]
+		case s[i] == '\\' && s[0] == '"':
+			i++
+		}
+	}
+	return s
+}
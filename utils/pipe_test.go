@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/aca/gosh/registry"
+)
+
+func init() {
+	// A function failing as a failed lookup does, without the network.
+	registry.Register("pipetest", (&registry.Func{
+		Name:      "lookup",
+		Signature: "func Lookup(host string) (addrs []string, err error)",
+		Params:    []registry.Param{{Name: "host", Type: "string"}},
+		Results:   []registry.Param{{Name: "addrs", Type: "[]string"}},
+	}).WithFunc(func(args []string) ([]interface{}, error) {
+		return nil, &net.DNSError{Err: "no such host", Name: args[0], IsNotFound: true}
+	}))
+}
+
+// The errors of the stages keep their exit status, as when the functions run
+// as commands.
+func TestPipelineRunExitCode(t *testing.T) {
+	tests := []struct {
+		expr string
+		exit int
+	}{
+		{"repeat y", ExitParse},
+		{"toupper | pipetest.lookup", ExitLookup},
+		{"toupper", ExitOK},
+	}
+	for _, tt := range tests {
+		pl, err := ParsePipeline(tt.expr, "gostrings", "pipetest")
+		if err != nil {
+			t.Fatalf("ParsePipeline(%q): %v", tt.expr, err)
+		}
+		_, err = pl.Run([]string{"x"})
+		if err != nil {
+			err = &runError{err}
+		}
+		if exit := ExitCode(err); exit != tt.exit {
+			t.Errorf("%q: got exit %d (%v), want %d", tt.expr, exit, err, tt.exit)
+		}
+	}
+}
+
+func TestParsePipeline(t *testing.T) {
+	tests := []struct {
+		expr  string
+		group string // of the first stage
+		err   string
+	}{
+		{expr: "trimspace | split , | toupper", group: "gostrings"},
+		{expr: `split "/" | gofilepath.join /root`, group: "gostrings"},
+		{expr: "gofilepath split | base", group: "gofilepath"},
+		{expr: "base", group: "gofilepath"},
+		{expr: "gofilepath.join", group: "gofilepath"},
+		{expr: "replace 'a b' `c` -1", group: "gostrings"},
+		{expr: "nosuch", err: `unknown function "nosuch"`},
+		{expr: "gofilepath.nosuch", err: `unknown function "gofilepath.nosuch"`},
+		{expr: "split / | base", err: `unknown function "base" in gostrings, write gofilepath.base to use the one of gofilepath`},
+		{expr: "toupper |", err: "empty pipeline stage"},
+		{expr: "split", err: "split: func Split(s, sep string) []string takes 1 argument(s) in a pipeline, got 0"},
+		{expr: "repeat", err: "repeat: func Repeat(s string, count int) string takes 1 argument(s) in a pipeline, got 0"},
+		{expr: "map toupper", err: "func Map(mapping func(rune) rune, s string) string can't be used in a pipeline"},
+		{expr: `toupper "a`, err: `invalid quoted string "a`},
+		{expr: "toupper 'a", err: `unterminated quoted string in "'a"`},
+	}
+	for _, tt := range tests {
+		pl, err := ParsePipeline(tt.expr, "gostrings", "gofilepath")
+		if tt.err != "" {
+			if err == nil || err.Error() != tt.err {
+				t.Errorf("ParsePipeline(%q): got error %v, want %s", tt.expr, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParsePipeline(%q): %v", tt.expr, err)
+			continue
+		}
+		if g := pl.Group(); g != tt.group {
+			t.Errorf("ParsePipeline(%q): group %s, want %s", tt.expr, g, tt.group)
+		}
+	}
+}
+
+func TestPipelineRun(t *testing.T) {
+	tests := []struct {
+		expr   string
+		values []string
+		want   []string
+	}{
+		{"trimspace | split , | toupper", []string{" a,b "}, []string{"A", "B"}},
+		{`split "/" | gofilepath.join /root`, []string{"x/y"}, []string{"/root/x/y"}},
+		{"split , | hasprefix a", []string{"ab,b,ac"}, []string{"ab", "ac"}},
+		{"cut =", []string{"k=v"}, []string{"k", "v", "true"}},
+		{"replace 'a b' `c` -1", []string{"a b a b"}, []string{"c c"}},
+		{"toupper", nil, nil},
+	}
+	for _, tt := range tests {
+		pl, err := ParsePipeline(tt.expr, "gostrings", "gofilepath")
+		if err != nil {
+			t.Fatalf("ParsePipeline(%q): %v", tt.expr, err)
+		}
+		got, err := pl.Run(tt.values)
+		if err != nil {
+			t.Errorf("%q of %q: %v", tt.expr, tt.values, err)
+			continue
+		}
+		if strings.Join(got, "\x00") != strings.Join(tt.want, "\x00") || len(got) != len(tt.want) {
+			t.Errorf("%q of %q = %q, want %q", tt.expr, tt.values, got, tt.want)
+		}
+	}
+}
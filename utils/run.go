@@ -0,0 +1,145 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// maxRecordSize bounds the size of a single input line in line mode.
+const maxRecordSize = 1 << 30
+
+// AddPersistentFlags registers the flags shared by every command of a group
+// on its root command.
+func AddPersistentFlags(root *cobra.Command) {
+	root.PersistentFlags().BoolP("lines", "l", false, "apply the command to each line of input")
+	root.PersistentFlags().BoolP("null", "z", false, "split input on NUL and terminate every result with NUL")
+	root.PersistentFlags().StringP("output", "o", "", "output format: "+strings.Join(OutputFormats, ", ")+", or "+templatePrefix+"TEXT")
+	root.PersistentFlags().String("template-file", "", "render results with the text/template in file, as -o "+templatePrefix+"TEXT")
+	root.PersistentFlags().BoolP("go-literal", "e", false, "decode arguments as Go string literals, @file reads an argument from file")
+	root.RegisterFlagCompletionFunc("output", completeValues(OutputFormats...))
+	root.MarkPersistentFlagFilename("template-file")
+	AddErrorFlags(root)
+}
+
+// Run returns a cobra RunE for a command taking nargs arguments.
+//
+// If only nargs-1 arguments are given, the first one is read from stdin. By
+// default the whole of stdin is used as a single value; in line mode fn is
+// called once per line, as lines are read, so inputs of any size are streamed.
+// NUL mode is line mode with NUL in place of newline, for both input and
+// output. In Go literal mode, arguments are decoded by DecodeArg first.
+func Run(nargs int, fn func(cmd *cobra.Command, args []string, p *Printer) error) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		output, err := outputFormat(cmd)
+		if err != nil {
+			return err
+		}
+		null, err := cmd.Flags().GetBool("null")
+		if err != nil {
+			return err
+		}
+
+		p, err := NewPrinter(cmd.OutOrStdout(), output)
+		if err != nil {
+			return err
+		}
+		if null {
+			p.term = "\x00"
+			p.records = true
+		}
+		if args, err = decodeArgs(cmd, args); err != nil {
+			return err
+		}
+
+		err = runInput(cmd, args, nargs, fn, p)
+		if ferr := p.Flush(); err == nil {
+			err = ferr
+		}
+		return err
+	}
+}
+
+// outputFormat returns the output format of cmd, with the template of
+// --template-file if given. The final newline of the file is dropped, as
+// every rendering is terminated already.
+func outputFormat(cmd *cobra.Command) (string, error) {
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return "", err
+	}
+	file, err := cmd.Flags().GetString("template-file")
+	if err != nil || file == "" {
+		return output, err
+	}
+	if cmd.Flags().Changed("output") {
+		return "", fmt.Errorf("%w: --template-file and --output are exclusive", ErrInvalidOutputFormat)
+	}
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+	return templatePrefix + strings.TrimSuffix(string(b), "\n"), nil
+}
+
+func runInput(cmd *cobra.Command, args []string, nargs int, fn func(cmd *cobra.Command, args []string, p *Printer) error, p *Printer) error {
+	if len(args) == nargs {
+		return fn(cmd, args, p)
+	}
+
+	lines, err := cmd.Flags().GetBool("lines")
+	if err != nil {
+		return err
+	}
+	null, err := cmd.Flags().GetBool("null")
+	if err != nil {
+		return err
+	}
+
+	if !lines && !null {
+		stdin, err := ioutil.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+		return fn(cmd, append([]string{string(stdin)}, args...), p)
+	}
+
+	p.records = true
+	sc := NewRecordScanner(cmd.InOrStdin(), null)
+	for sc.Scan() {
+		if err := fn(cmd, append([]string{sc.Text()}, args...), p); err != nil {
+			return err
+		}
+	}
+	return sc.Err()
+}
+
+// NewRecordScanner returns a Scanner reading the records of r, lines or
+// NUL-terminated strings if null is set, of up to maxRecordSize bytes.
+func NewRecordScanner(r io.Reader, null bool) *bufio.Scanner {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), maxRecordSize)
+	if null {
+		sc.Split(scanNull)
+	}
+	return sc
+}
+
+// scanNull is a bufio.SplitFunc that splits input on NUL bytes.
+func scanNull(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexByte(data, 0); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
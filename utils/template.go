@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// templatePrefix starts the output format rendering results with a template.
+const templatePrefix = "template="
+
+// templateFuncs are the functions available to output templates, besides the
+// builtins of text/template. Those taking a string take it last, so that they
+// can end a pipeline: {{.Host | trimprefix "www."}}.
+var templateFuncs = template.FuncMap{
+	"json": func(v interface{}) (string, error) {
+		b, err := marshalJSON(normalize(v))
+		return string(b), err
+	},
+	"text":  text,
+	"shell": func(v interface{}) string { return ShellQuote(text(v)) },
+	"join": func(sep string, v interface{}) string {
+		l, ok := normalize(v).([]interface{})
+		if !ok {
+			return text(v)
+		}
+		elems := make([]string, len(l))
+		for i, e := range l {
+			elems[i] = text(e)
+		}
+		return strings.Join(elems, sep)
+	},
+	"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+	"upper":      strings.ToUpper,
+	"lower":      strings.ToLower,
+	"trim":       strings.TrimSpace,
+	"trimprefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+	"trimsuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+	"replace":    func(old, new, s string) string { return strings.Replace(s, old, new, -1) },
+	"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+	"hasprefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+	"hassuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+}
+
+// parseTemplate parses the text of an output template. Errors are invalid
+// output formats.
+func parseTemplate(text string) (*template.Template, error) {
+	t, err := template.New("output").Funcs(templateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOutputFormat, err)
+	}
+	return t, nil
+}
+
+// templateData returns the value a template is executed with for a result v:
+// v itself, so that the fields and methods of structs are available, or a map
+// of the fields of a Record by name.
+func templateData(v interface{}) interface{} {
+	r, ok := v.(Record)
+	if !ok {
+		return v
+	}
+	m := make(map[string]interface{}, len(r))
+	for _, f := range r {
+		m[f.Name] = f.Value
+	}
+	return m
+}
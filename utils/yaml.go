@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// yamlDocument returns a normalized value as a YAML document.
+func yamlDocument(n interface{}) string {
+	switch v := n.(type) {
+	case []interface{}:
+		if len(v) > 0 {
+			return yamlBlock(v, 0)
+		}
+	case Record:
+		if len(v) > 0 {
+			return yamlBlock(v, 0)
+		}
+	}
+	return yamlInline(n) + "\n"
+}
+
+// yamlBlock returns a non-empty list or Record as block lines indented by
+// indent spaces.
+func yamlBlock(n interface{}, indent int) string {
+	sb := &strings.Builder{}
+	pad := strings.Repeat(" ", indent)
+
+	switch v := n.(type) {
+	case []interface{}:
+		for _, e := range v {
+			if !yamlNested(e) {
+				sb.WriteString(pad + "- " + yamlInline(e) + "\n")
+				continue
+			}
+			// The first line of the nested block goes right after the dash.
+			sub := yamlBlock(e, indent+2)
+			sb.WriteString(pad + "- " + sub[indent+2:])
+		}
+	case Record:
+		for _, f := range v {
+			key := yamlScalar(f.Name)
+			if !yamlNested(f.Value) {
+				sb.WriteString(pad + key + ": " + yamlInline(f.Value) + "\n")
+				continue
+			}
+			sb.WriteString(pad + key + ":\n")
+			if _, ok := f.Value.([]interface{}); ok {
+				sb.WriteString(yamlBlock(f.Value, indent))
+			} else {
+				sb.WriteString(yamlBlock(f.Value, indent+2))
+			}
+		}
+	}
+	return sb.String()
+}
+
+// yamlNested reports whether n is written as a block rather than inline.
+func yamlNested(n interface{}) bool {
+	switch v := n.(type) {
+	case []interface{}:
+		return len(v) > 0
+	case Record:
+		return len(v) > 0
+	}
+	return false
+}
+
+func yamlInline(n interface{}) string {
+	switch v := n.(type) {
+	case nil:
+		return "null"
+	case string:
+		return yamlScalar(v)
+	case []interface{}:
+		return "[]"
+	case Record:
+		return "{}"
+	}
+	return fmt.Sprint(n)
+}
+
+// yamlScalar returns s as a plain scalar if it can't be read back as anything
+// but the same string, or double-quoted otherwise.
+func yamlScalar(s string) string {
+	if s == "" || strings.TrimSpace(s) != s {
+		return strconv.Quote(s)
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~", "y", "n":
+		return strconv.Quote(s)
+	}
+	if strings.ContainsAny(s[:1], "-?:,[]{}#&*!|>'\"%@`0123456789.+") {
+		return strconv.Quote(s)
+	}
+	if strings.Contains(s, ": ") || strings.Contains(s, " #") || strings.HasSuffix(s, ":") {
+		return strconv.Quote(s)
+	}
+	for _, r := range s {
+		if !unicode.IsPrint(r) {
+			return strconv.Quote(s)
+		}
+	}
+	return s
+}